@@ -0,0 +1,199 @@
+// Package capture records OBD traffic to a capture file, and replays it back as a transport.
+//
+// A capture file is JSON lines. The first line is a header with the format version, and every following
+// line is a single Entry: either a request/response exchange, or a chunk of the raw byte stream to or from an adapter.
+package capture
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/mastercactapus/obd2"
+)
+
+// Version is the capture format version written by Writer
+const Version = 1
+
+// format identifies capture files in the header
+const format = "obd2-capture"
+
+// ErrBadHeader is returned when reading something that is not a capture file
+var ErrBadHeader = errors.New("not a capture file")
+
+// EntryType is the kind of an Entry
+type EntryType string
+
+const (
+	// EntryExchange is a request and the response (or error) it got
+	EntryExchange EntryType = "exchange"
+
+	// EntryRaw is a chunk of the raw byte stream
+	EntryRaw EntryType = "raw"
+)
+
+// Direction is the direction raw data was sent
+type Direction string
+
+const (
+	// DirectionTx is data written to the adapter
+	DirectionTx Direction = "tx"
+
+	// DirectionRx is data read from the adapter
+	DirectionRx Direction = "rx"
+)
+
+// Bytes is a byte slice that is stored as a hex string
+type Bytes []byte
+
+func (b Bytes) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(b)), nil
+}
+
+func (b *Bytes) UnmarshalText(text []byte) error {
+	data, err := hex.DecodeString(string(text))
+	*b = data
+	return err
+}
+
+// Request is a recorded obd2.Request
+type Request struct {
+	Mode    byte         `json:"mode"`
+	Args    Bytes        `json:"args,omitempty"`
+	Address obd2.Address `json:"address,omitempty"`
+}
+
+// Message is a recorded obd2.Message
+type Message struct {
+	Source obd2.Address `json:"source"`
+	Data   Bytes        `json:"data"`
+}
+
+// Entry is a single line of a capture file
+type Entry struct {
+	Type EntryType `json:"type"`
+	Time time.Time `json:"time"`
+
+	// Request, Messages and Error are set for EntryExchange. Error is the text of the error returned, if any.
+	// ErrorKind identifies known errors (e.g. "elm327-no-data"), and Negative is set for an obd2.NegativeResponse,
+	// so a Replayer can return an error that matches with errors.Is and errors.As
+	Request   *Request  `json:"request,omitempty"`
+	Messages  []Message `json:"messages,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	ErrorKind string    `json:"errorKind,omitempty"`
+	Negative  *Negative `json:"negative,omitempty"`
+
+	// Direction and Data are set for EntryRaw
+	Direction Direction `json:"dir,omitempty"`
+	Data      Bytes     `json:"data,omitempty"`
+}
+
+type header struct {
+	Format  string    `json:"format"`
+	Version int       `json:"version"`
+	Created time.Time `json:"created"`
+}
+
+// Writer writes entries to a capture file. It is safe for concurrent use
+type Writer struct {
+	mx  sync.Mutex
+	enc *json.Encoder
+	now func() time.Time
+}
+
+// NewWriter will write the capture header to w, and return a Writer for the entries
+func NewWriter(w io.Writer) (*Writer, error) {
+	cw := &Writer{enc: json.NewEncoder(w), now: time.Now}
+	err := cw.enc.Encode(header{Format: format, Version: Version, Created: cw.now()})
+	if err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+// Write will write e to the capture. If e.Time is zero, it is set to the current time
+func (w *Writer) Write(e Entry) error {
+	w.mx.Lock()
+	defer w.mx.Unlock()
+	if e.Time.IsZero() {
+		e.Time = w.now()
+	}
+	return w.enc.Encode(e)
+}
+
+// WriteExchange will record req and the response or error it got
+func (w *Writer) WriteExchange(req *obd2.Request, res *obd2.Response, err error) error {
+	e := Entry{
+		Type:    EntryExchange,
+		Request: &Request{Mode: req.Mode, Args: req.Args, Address: req.Address},
+	}
+	if res != nil {
+		for _, m := range res.Messages {
+			e.Messages = append(e.Messages, Message{Source: m.Source, Data: m.Data})
+		}
+	}
+	if err != nil {
+		e.setError(err)
+	}
+	return w.Write(e)
+}
+
+// WriteRaw will record a chunk of the raw byte stream
+func (w *Writer) WriteRaw(dir Direction, data []byte) error {
+	return w.Write(Entry{Type: EntryRaw, Direction: dir, Data: append(Bytes(nil), data...)})
+}
+
+// Reader reads entries from a capture file
+type Reader struct {
+	dec *json.Decoder
+
+	// Created is the time the capture was started
+	Created time.Time
+}
+
+// NewReader will read and validate the capture header from r
+func NewReader(r io.Reader) (*Reader, error) {
+	dec := json.NewDecoder(bufio.NewReader(r))
+	var h header
+	err := dec.Decode(&h)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadHeader, err)
+	}
+	if h.Format != format {
+		return nil, ErrBadHeader
+	}
+	if h.Version != Version {
+		return nil, fmt.Errorf("unsupported capture version %d", h.Version)
+	}
+	return &Reader{dec: dec, Created: h.Created}, nil
+}
+
+// Next will return the next entry, or io.EOF at the end of the capture
+func (r *Reader) Next() (*Entry, error) {
+	var e Entry
+	err := r.dec.Decode(&e)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// ReadAll will return all remaining entries
+func (r *Reader) ReadAll() ([]Entry, error) {
+	var entries []Entry
+	for {
+		e, err := r.Next()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return entries, err
+		}
+		entries = append(entries, *e)
+	}
+}
@@ -0,0 +1,110 @@
+package capture
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/mastercactapus/obd2"
+	"github.com/mastercactapus/obd2/elm327"
+	"github.com/mastercactapus/obd2/isotp"
+)
+
+// kindNegative is the ErrorKind of an obd2.NegativeResponse. The mode and code are kept in Entry.Negative
+const kindNegative = "negative-response"
+
+// errorKinds lists the errors that are recorded by kind, so they can be matched with errors.Is when replayed
+var errorKinds = []struct {
+	kind string
+	err  error
+}{
+	{"no-response", obd2.ErrNoResponse},
+	{"canceled", context.Canceled},
+	{"deadline-exceeded", context.DeadlineExceeded},
+	{"eof", io.EOF},
+	{"elm327-unknown-command", elm327.ErrUnknownCommand},
+	{"elm327-not-supported", elm327.ErrNotSupported},
+	{"elm327-no-data", elm327.ErrNoData},
+	{"elm327-can-error", elm327.ErrCANError},
+	{"elm327-bus-init", elm327.ErrBusInit},
+	{"elm327-bus-busy", elm327.ErrBusBusy},
+	{"elm327-bus-error", elm327.ErrBusError},
+	{"elm327-unable-to-connect", elm327.ErrUnableToConnect},
+	{"elm327-buffer-full", elm327.ErrBufferFull},
+	{"elm327-stopped", elm327.ErrStopped},
+	{"elm327-data-error", elm327.ErrDataError},
+	{"elm327-fb-error", elm327.ErrFBError},
+	{"elm327-lv-reset", elm327.ErrLVReset},
+	{"elm327-rx-error", elm327.ErrRXError},
+	{"elm327-activity-alert", elm327.ErrActivityAlert},
+	{"elm327-low-power-alert", elm327.ErrLowPowerAlert},
+	{"elm327-incomplete-response", elm327.ErrIncompleteResponse},
+	{"isotp-timeout", isotp.ErrTimeout},
+	{"isotp-overflow", isotp.ErrOverflow},
+	{"isotp-sequence", isotp.ErrSequence},
+	{"isotp-too-long", isotp.ErrTooLong},
+}
+
+// Negative is a recorded obd2.NegativeResponse
+type Negative struct {
+	Mode byte `json:"mode"`
+	Code byte `json:"code"`
+}
+
+// setError will record err in e, along with its kind if it is a known error
+func (e *Entry) setError(err error) {
+	e.Error = err.Error()
+	var neg obd2.NegativeResponse
+	if errors.As(err, &neg) {
+		e.ErrorKind = kindNegative
+		e.Negative = &Negative{Mode: neg.Mode, Code: neg.Code}
+		return
+	}
+	for _, k := range errorKinds {
+		if errors.Is(err, k.err) {
+			e.ErrorKind = k.kind
+			return
+		}
+	}
+}
+
+// err will return the recorded error. Errors of a known kind are returned as the original error if the text
+// matches, otherwise the text is kept and the original error is wrapped. Anything else only keeps the text
+func (e *Entry) err() error {
+	if e.Error == "" {
+		return nil
+	}
+	var err error
+	switch {
+	case e.ErrorKind == kindNegative && e.Negative != nil:
+		err = obd2.NegativeResponse{Mode: e.Negative.Mode, Code: e.Negative.Code}
+	case e.ErrorKind != "":
+		for _, k := range errorKinds {
+			if k.kind == e.ErrorKind {
+				err = k.err
+				break
+			}
+		}
+	}
+	if err == nil {
+		return errors.New(e.Error)
+	}
+	if err.Error() == e.Error {
+		return err
+	}
+	return &replayedError{text: e.Error, err: err}
+}
+
+// replayedError is a recorded error that wrapped a known error
+type replayedError struct {
+	text string
+	err  error
+}
+
+func (e *replayedError) Error() string {
+	return e.text
+}
+
+func (e *replayedError) Unwrap() error {
+	return e.err
+}
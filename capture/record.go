@@ -0,0 +1,73 @@
+package capture
+
+import (
+	"context"
+	"io"
+
+	"github.com/mastercactapus/obd2"
+)
+
+// Recorder is an obd2.Transport that records every exchange made through it
+type Recorder struct {
+	t obd2.Transport
+	w *Writer
+}
+
+// NewRecorder will create a Recorder that sends requests with t and records them to w
+func NewRecorder(t obd2.Transport, w *Writer) *Recorder {
+	return &Recorder{t: t, w: w}
+}
+
+// RoundTrip will send req with the underlying transport and record the result. It satisfies the obd2.Transport interface
+func (r *Recorder) RoundTrip(req *obd2.Request) (*obd2.Response, error) {
+	return r.RoundTripContext(context.Background(), req)
+}
+
+// RoundTripContext is like RoundTrip, but passes ctx to the underlying transport if it supports it.
+// It satisfies the obd2.ContextTransport interface
+func (r *Recorder) RoundTripContext(ctx context.Context, req *obd2.Request) (*obd2.Response, error) {
+	var res *obd2.Response
+	var err error
+	if t, ok := r.t.(obd2.ContextTransport); ok {
+		res, err = t.RoundTripContext(ctx, req)
+	} else if err = ctx.Err(); err == nil {
+		res, err = r.t.RoundTrip(req)
+	}
+	werr := r.w.WriteExchange(req, res, err)
+	if err != nil {
+		return nil, err
+	}
+	return res, werr
+}
+
+// rawRecorder records the raw byte stream of an adapter connection
+type rawRecorder struct {
+	rw io.ReadWriter
+	w  *Writer
+}
+
+// NewRawRecorder will wrap an adapter connection (e.g. the serial port passed to elm327.New) so every byte
+// read from and written to it is recorded to w
+func NewRawRecorder(rw io.ReadWriter, w *Writer) io.ReadWriter {
+	return &rawRecorder{rw: rw, w: w}
+}
+
+func (r *rawRecorder) Read(p []byte) (int, error) {
+	n, err := r.rw.Read(p)
+	if n > 0 {
+		if werr := r.w.WriteRaw(DirectionRx, p[:n]); werr != nil && err == nil {
+			err = werr
+		}
+	}
+	return n, err
+}
+
+func (r *rawRecorder) Write(p []byte) (int, error) {
+	n, err := r.rw.Write(p)
+	if n > 0 {
+		if werr := r.w.WriteRaw(DirectionTx, p[:n]); werr != nil && err == nil {
+			err = werr
+		}
+	}
+	return n, err
+}
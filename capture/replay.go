@@ -0,0 +1,142 @@
+package capture
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/mastercactapus/obd2"
+)
+
+// ErrMismatch is returned when a replayed request or write doesn't match the capture
+var ErrMismatch = errors.New("does not match capture")
+
+// Replayer is an obd2.Transport that answers with the exchanges of a capture, in order
+type Replayer struct {
+	mx      sync.Mutex
+	entries []Entry
+}
+
+// NewReplayer will create a Replayer from the exchange entries in entries. Other entries are ignored
+func NewReplayer(entries []Entry) *Replayer {
+	r := new(Replayer)
+	for _, e := range entries {
+		if e.Type == EntryExchange && e.Request != nil {
+			r.entries = append(r.entries, e)
+		}
+	}
+	return r
+}
+
+// Remaining will return the number of exchanges that have not been replayed
+func (r *Replayer) Remaining() int {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	return len(r.entries)
+}
+
+// RoundTrip will return the response of the next recorded exchange. If req does not match the
+// recorded request, ErrMismatch is returned and the exchange is not consumed. It satisfies the obd2.Transport interface
+func (r *Replayer) RoundTrip(req *obd2.Request) (*obd2.Response, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	if len(r.entries) == 0 {
+		return nil, io.EOF
+	}
+	e := r.entries[0]
+	if e.Request.Mode != req.Mode || e.Request.Address != req.Address || !bytes.Equal(e.Request.Args, req.Args) {
+		return nil, fmt.Errorf("request mode 0x%02x args % x: %w", req.Mode, req.Args, ErrMismatch)
+	}
+	r.entries = r.entries[1:]
+	if err := e.err(); err != nil {
+		return nil, err
+	}
+	res := new(obd2.Response)
+	for _, m := range e.Messages {
+		res.Messages = append(res.Messages, obd2.Message{Source: m.Source, Data: append([]byte(nil), m.Data...)})
+	}
+	return res, nil
+}
+
+// RawReplayer is an io.ReadWriter that plays back a recorded adapter byte stream. Writes must match the
+// recorded data, and recorded reads only become available once the writes before them have been made
+type RawReplayer struct {
+	mx      sync.Mutex
+	cond    *sync.Cond
+	entries []Entry
+	closed  bool
+}
+
+// NewRawReplayer will create a RawReplayer from the raw entries in entries. Other entries are ignored
+func NewRawReplayer(entries []Entry) *RawReplayer {
+	r := new(RawReplayer)
+	r.cond = sync.NewCond(&r.mx)
+	for _, e := range entries {
+		if e.Type == EntryRaw && len(e.Data) > 0 {
+			r.entries = append(r.entries, Entry{Type: e.Type, Direction: e.Direction, Data: append(Bytes(nil), e.Data...)})
+		}
+	}
+	return r
+}
+
+// Read will return recorded data read from the adapter. It blocks while the capture is waiting for a write
+func (r *RawReplayer) Read(p []byte) (int, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	for !r.closed && len(r.entries) > 0 && r.entries[0].Direction != DirectionRx {
+		r.cond.Wait()
+	}
+	if r.closed || len(r.entries) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.entries[0].Data)
+	r.entries[0].Data = r.entries[0].Data[n:]
+	if len(r.entries[0].Data) == 0 {
+		r.entries = r.entries[1:]
+		r.cond.Broadcast()
+	}
+	return n, nil
+}
+
+// Write will check p against the recorded data written to the adapter. It blocks while the capture
+// has data to be read first
+func (r *RawReplayer) Write(p []byte) (int, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	written := 0
+	for len(p) > 0 {
+		for !r.closed && len(r.entries) > 0 && r.entries[0].Direction != DirectionTx {
+			r.cond.Wait()
+		}
+		if r.closed || len(r.entries) == 0 {
+			return written, io.ErrClosedPipe
+		}
+		data := r.entries[0].Data
+		n := len(p)
+		if n > len(data) {
+			n = len(data)
+		}
+		if !bytes.Equal(p[:n], data[:n]) {
+			return written, fmt.Errorf("write %q: %w", p, ErrMismatch)
+		}
+		r.entries[0].Data = data[n:]
+		if len(r.entries[0].Data) == 0 {
+			r.entries = r.entries[1:]
+			r.cond.Broadcast()
+		}
+		written += n
+		p = p[n:]
+	}
+	return written, nil
+}
+
+// Close will unblock any pending reads or writes
+func (r *RawReplayer) Close() error {
+	r.mx.Lock()
+	r.closed = true
+	r.cond.Broadcast()
+	r.mx.Unlock()
+	return nil
+}
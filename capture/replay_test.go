@@ -0,0 +1,67 @@
+package capture
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mastercactapus/obd2"
+	"github.com/mastercactapus/obd2/elm327"
+)
+
+func TestReplayErrors(t *testing.T) {
+	neg := obd2.NegativeResponse{Mode: 0x04, Code: 0x22}
+	errs := []error{
+		elm327.ErrNoData,
+		obd2.ErrNoResponse,
+		fmt.Errorf("AT%s: %w", "SP6", elm327.ErrBusInit),
+		neg,
+		fmt.Errorf("ECU 7E8: %w", neg),
+		errors.New("something else"),
+	}
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &obd2.Request{Mode: 0x01, Args: []byte{0x0c}}
+	for _, e := range errs {
+		err = w.WriteExchange(req, nil, e)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	r, err := NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := r.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rp := NewReplayer(entries)
+	for _, want := range errs {
+		_, err := rp.RoundTrip(req)
+		if err == nil || err.Error() != want.Error() {
+			t.Fatalf("got %v; want %v", err, want)
+		}
+		var wantNeg, gotNeg obd2.NegativeResponse
+		switch {
+		case errors.As(want, &wantNeg):
+			if !errors.As(err, &gotNeg) || gotNeg != wantNeg {
+				t.Errorf("%v: got NegativeResponse %+v; want %+v", want, gotNeg, wantNeg)
+			}
+		case errors.Unwrap(want) != nil:
+			if !errors.Is(err, errors.Unwrap(want)) {
+				t.Errorf("%v: does not match %v", err, errors.Unwrap(want))
+			}
+		case want == elm327.ErrNoData || want == obd2.ErrNoResponse:
+			if err != want {
+				t.Errorf("got %#v; want the sentinel %v", err, want)
+			}
+		}
+	}
+}
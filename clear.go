@@ -0,0 +1,99 @@
+package obd2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ModeClear is the mode to clear emissions-related diagnostic information (mode 04)
+const ModeClear byte = 0x04
+
+// NRCConditionsNotCorrect is the NegativeResponse code an ECU sends when it refuses to clear, usually because the
+// engine is running
+const NRCConditionsNotCorrect byte = 0x22
+
+var (
+	// ErrClearNotConfirmed is returned by ClearDTCs unless ClearOptions.Confirm is set
+	ErrClearNotConfirmed = errors.New("clearing diagnostic information was not confirmed")
+
+	// ErrEngineRunning is returned by ClearDTCs if the engine RPM is not zero
+	ErrEngineRunning = errors.New("engine is running")
+)
+
+// ClearOptions control ClearDTCs
+type ClearOptions struct {
+	// Confirm must be set for anything to be cleared. Clearing erases the stored and pending trouble codes, freeze frames,
+	// and readiness monitor status of every ECU; the vehicle will not pass an emissions inspection until the monitors
+	// complete again
+	Confirm bool
+
+	// SkipRPMCheck will clear without first checking that the engine is off. Normally, ClearDTCs will refuse if
+	// mode1.PIDEngineRPM reports a non-zero value. The check is skipped if no ECU supports the PID
+	SkipRPMCheck bool
+
+	// Snapshot, if set, is called before clearing so the caller can save the trouble codes and freeze frames
+	// (e.g. with mode3.ReadAll and mode2.Read). Nothing is cleared if it returns an error
+	Snapshot func(ctx context.Context, c *Client) error
+}
+
+// ClearResult is the reply of a single ECU to ClearDTCs
+type ClearResult struct {
+	// Source is the address of the ECU
+	Source Address
+
+	// Err is nil if the ECU acknowledged the request. Otherwise it is the reason it was rejected,
+	// usually a NegativeResponse with NRCConditionsNotCorrect
+	Err error
+}
+
+// ClearDTCs will clear the emissions-related diagnostic information of every ECU (mode 04), and return the reply of each.
+// Rejections by individual ECUs are reported in the results rather than as an error
+func (c *Client) ClearDTCs(ctx context.Context, opts ClearOptions) ([]ClearResult, error) {
+	if !opts.Confirm {
+		return nil, ErrClearNotConfirmed
+	}
+	if !opts.SkipRPMCheck {
+		err := c.checkEngineOff(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if opts.Snapshot != nil {
+		err := opts.Snapshot(ctx, c)
+		if err != nil {
+			return nil, fmt.Errorf("snapshot: %w", err)
+		}
+	}
+
+	res, err := c.roundTrip(ctx, &Request{Mode: ModeClear})
+	if err != nil {
+		return nil, err
+	}
+	if res == nil || len(res.Messages) == 0 {
+		return nil, ErrNoResponse
+	}
+	results := make([]ClearResult, 0, len(res.Messages))
+	for _, m := range res.Messages {
+		_, err := m.Payload(ModeClear)
+		results = append(results, ClearResult{Source: m.Source, Err: err})
+	}
+	return results, nil
+}
+
+// checkEngineOff will return ErrEngineRunning if the engine RPM is not zero. It returns nil if the RPM can't be read
+// because no ECU supports it
+func (c *Client) checkEngineOff(ctx context.Context) error {
+	rpm, err := c.EngineRPM(ctx)
+	var neg NegativeResponse
+	if errors.Is(err, ErrNoResponse) || errors.As(err, &neg) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("check engine RPM: %w", err)
+	}
+	if rpm != 0 {
+		return fmt.Errorf("%w: %v RPM", ErrEngineRunning, rpm)
+	}
+	return nil
+}
@@ -1,25 +1,34 @@
 package elm327
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"io"
 	"strconv"
 	"strings"
 )
 
 // Description will return the device description
 func (c *ELM327) Description() (string, error) {
+	if err := c.require("@1"); err != nil {
+		return "", err
+	}
 	return c.SendAT("@1")
 }
 
 // ID will return the device identifier
 func (c *ELM327) ID() (string, error) {
+	if err := c.require("@2"); err != nil {
+		return "", err
+	}
 	return c.SendAT("@2")
 }
 
 // ReadVoltage will read the input voltage
 func (c *ELM327) ReadVoltage() (float64, error) {
+	if err := c.require("RV"); err != nil {
+		return 0, err
+	}
 	res, err := c.SendAT("RV")
 	if err != nil {
 		return 0, err
@@ -38,35 +47,35 @@ func (c *ELM327) CalibrateVoltage(v float64) error {
 	return err
 }
 
+// GetIgnition will return the state of the IgnMon input
 func (c *ELM327) GetIgnition() (bool, error) {
+	if err := c.require("IGN"); err != nil {
+		return false, err
+	}
 	res, err := c.SendAT("IGN")
 	return res == "ON", err
 }
 
+// SendAT will send an AT command (without the AT prefix) and return the response
 func (c *ELM327) SendAT(command string) (string, error) {
-	command = "AT" + command + "\r"
-	if c.last == command {
-		command = "\r"
-	} else {
-		c.last = command
-	}
-	_, err := io.WriteString(c.w, command)
-	if err != nil {
-		return "", err
-	}
-	response, err := c.r.ReadString('>')
+	return c.SendATContext(context.Background(), command)
+}
+
+// SendATContext is like SendAT, but will give up waiting for the response when ctx is done
+func (c *ELM327) SendATContext(ctx context.Context, command string) (string, error) {
+	response, err := c.send(ctx, "AT"+command)
 	if err != nil {
 		return "", err
 	}
-	response = strings.TrimSpace(response[len(command) : len(response)-1])
-	// according to docs, this can happen and should be ignored...
-	response = strings.Replace(response, "\x00", "", -1)
-	if response == "?" {
-		return response, ErrUnknownCommand
+	if err = responseError(response); err != nil {
+		return response, err
 	}
 	return response, nil
 }
+
+// Reset will reset the device. Any settings made by Init will be lost
 func (c *ELM327) Reset() error {
 	_, err := c.SendAT("Z")
+	c.resetState()
 	return err
 }
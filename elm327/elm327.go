@@ -2,11 +2,12 @@ package elm327
 
 import (
 	"bufio"
-	"errors"
+	"context"
 	"io"
-)
+	"strings"
 
-var ErrUnknownCommand = errors.New("invalid or unknown command")
+	"github.com/mastercactapus/obd2"
+)
 
 type stringReader interface {
 	ReadString(delim byte) (string, error)
@@ -15,10 +16,33 @@ type ELM327 struct {
 	r    stringReader
 	w    io.Writer
 	last string
+
+	// caps is set once the device has been initialized
+	caps *Capabilities
+
+	// headers is set if headers are turned on
+	headers bool
+
+	// proto is the active protocol, once known
+	proto *Protocol
+
+	// target is the address requests are currently sent to
+	target obd2.Address
+
+	// lock guards the device so only one exchange is in progress at a time
+	lock chan struct{}
+
+	// pending holds the result of an outstanding read, if a previous exchange was cancelled before the prompt was received
+	pending chan readResult
+}
+
+type readResult struct {
+	s   string
+	err error
 }
 
 func New(rw io.ReadWriter) *ELM327 {
-	c := &ELM327{w: rw}
+	c := &ELM327{w: rw, lock: make(chan struct{}, 1)}
 	if r, ok := rw.(stringReader); ok {
 		c.r = r
 	} else {
@@ -26,3 +50,71 @@ func New(rw io.ReadWriter) *ELM327 {
 	}
 	return c
 }
+
+// resetState will forget any settings made since the device was last reset
+func (c *ELM327) resetState() {
+	c.caps = nil
+	c.headers = false
+	c.proto = nil
+	c.target = obd2.AddressFunctional
+}
+
+// readPrompt will read everything up to and including the next prompt. If ctx is cancelled first, the read is kept
+// pending so the next exchange can discard it and stay in sync with the device
+func (c *ELM327) readPrompt(ctx context.Context) (string, error) {
+	if c.pending == nil {
+		ch := make(chan readResult, 1)
+		go func() {
+			s, err := c.r.ReadString('>')
+			ch <- readResult{s: s, err: err}
+		}()
+		c.pending = ch
+	}
+	select {
+	case res := <-c.pending:
+		c.pending = nil
+		return res.s, res.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// send will write a command to the device and wait for the prompt, returning everything
+// in between with the echo and surrounding whitespace removed
+func (c *ELM327) send(ctx context.Context, command string) (string, error) {
+	select {
+	case c.lock <- struct{}{}:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	defer func() { <-c.lock }()
+
+	if c.pending != nil {
+		// a previous exchange was cancelled, wait for its prompt before sending anything new
+		_, err := c.readPrompt(ctx)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	command += "\r"
+	if c.last == command {
+		command = "\r"
+	} else {
+		c.last = command
+	}
+	_, err := io.WriteString(c.w, command)
+	if err != nil {
+		return "", err
+	}
+	response, err := c.readPrompt(ctx)
+	if err != nil {
+		// don't rely on repeating a command whose result we never saw
+		c.last = ""
+		return "", err
+	}
+	response = strings.TrimPrefix(response[:len(response)-1], command)
+	// according to docs, this can happen and should be ignored...
+	response = strings.Replace(response, "\x00", "", -1)
+	return strings.TrimSpace(response), nil
+}
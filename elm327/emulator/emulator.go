@@ -0,0 +1,406 @@
+// Package emulator provides an emulated ELM327 that speaks the AT protocol over any io.ReadWriter, forwarding
+// OBD requests to an obd2.Transport such as a simulated vehicle.
+package emulator
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/mastercactapus/obd2"
+	"github.com/mastercactapus/obd2/elm327"
+)
+
+// Emulator answers like an ELM327 connected to a vehicle
+type Emulator struct {
+	// Backend answers OBD requests
+	Backend obd2.Transport
+
+	// Protocol is the protocol the vehicle speaks. Defaults to elm327.ProtocolCAN11At500
+	Protocol elm327.Protocol
+
+	// Version is reported by ATI and on reset. Defaults to "ELM327 v1.5"
+	Version string
+
+	// Description is reported by AT@1. Defaults to "OBDII to RS232 Interpreter"
+	Description string
+
+	// Voltage is reported by ATRV. Defaults to 12.6
+	Voltage float64
+}
+
+// New will create an Emulator with default settings that forwards OBD requests to backend
+func New(backend obd2.Transport) *Emulator {
+	return &Emulator{
+		Backend:     backend,
+		Protocol:    elm327.ProtocolCAN11At500,
+		Version:     "ELM327 v1.5",
+		Description: "OBDII to RS232 Interpreter",
+		Voltage:     12.6,
+	}
+}
+
+// session holds the settings of a single connection
+type session struct {
+	e *Emulator
+	w *bufio.Writer
+
+	echo      bool
+	linefeeds bool
+	spaces    bool
+	headers   bool
+	caf       bool
+	proto     elm327.Protocol
+	auto      bool
+	found     bool
+	header    uint32
+	priority  byte
+	receive   string
+	last      string
+}
+
+func (s *session) reset() {
+	s.echo = true
+	s.linefeeds = false
+	s.spaces = true
+	s.headers = false
+	s.caf = true
+	s.proto = elm327.ProtocolAuto
+	s.auto = true
+	s.found = false
+	s.header = 0
+	s.priority = 0x18
+	s.receive = ""
+}
+
+// Serve will read commands from rw and write responses until reading fails. It returns nil if rw reaches io.EOF
+func (e *Emulator) Serve(rw io.ReadWriter) error {
+	r := bufio.NewReader(rw)
+	s := &session{e: e, w: bufio.NewWriter(rw)}
+	s.reset()
+
+	var line []byte
+	for {
+		b, err := r.ReadByte()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if b != '\r' {
+			// spaces and control characters are ignored
+			if b > ' ' {
+				line = append(line, b)
+			}
+			continue
+		}
+
+		cmd := strings.ToUpper(string(line))
+		line = line[:0]
+		if s.echo {
+			s.w.WriteString(cmd + "\r")
+		}
+		if cmd == "" {
+			cmd = s.last
+		} else {
+			s.last = cmd
+		}
+		for _, l := range s.exec(cmd) {
+			s.w.WriteString(l + s.eol())
+		}
+		s.w.WriteString(s.eol() + ">")
+		err = s.w.Flush()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *session) eol() string {
+	if s.linefeeds {
+		return "\r\n"
+	}
+	return "\r"
+}
+
+// exec will run cmd and return the lines of the response
+func (s *session) exec(cmd string) []string {
+	if cmd == "" {
+		return nil
+	}
+	if strings.HasPrefix(cmd, "AT") {
+		return s.at(cmd[2:])
+	}
+	return s.obd(cmd)
+}
+
+// at will run an AT command (without the AT prefix)
+func (s *session) at(cmd string) []string {
+	ok := []string{"OK"}
+	flag := func(v *bool) []string {
+		switch cmd[len(cmd)-1] {
+		case '0':
+			*v = false
+		case '1':
+			*v = true
+		default:
+			return []string{"?"}
+		}
+		return ok
+	}
+	switch {
+	case cmd == "Z", cmd == "WS":
+		s.reset()
+		return []string{"", s.e.Version}
+	case cmd == "D":
+		s.reset()
+		return ok
+	case cmd == "I":
+		return []string{s.e.Version}
+	case cmd == "@1":
+		return []string{s.e.Description}
+	case cmd == "RV":
+		return []string{fmt.Sprintf("%.1fV", s.e.Voltage)}
+	case cmd == "IGN":
+		return []string{"ON"}
+	case cmd == "DP":
+		name := s.current().String()
+		if s.auto {
+			name = "AUTO, " + name
+		}
+		return []string{name}
+	case cmd == "DPN":
+		n := fmt.Sprintf("%X", byte(s.current()))
+		if s.auto {
+			n = "A" + n
+		}
+		return []string{n}
+	case len(cmd) == 2 && cmd[0] == 'E':
+		return flag(&s.echo)
+	case len(cmd) == 2 && cmd[0] == 'L':
+		return flag(&s.linefeeds)
+	case len(cmd) == 2 && cmd[0] == 'S':
+		return flag(&s.spaces)
+	case len(cmd) == 2 && cmd[0] == 'H':
+		return flag(&s.headers)
+	case cmd == "CAF0", cmd == "CAF1":
+		return flag(&s.caf)
+	case strings.HasPrefix(cmd, "SP"), strings.HasPrefix(cmd, "TP"):
+		return s.setProtocol(cmd[2:])
+	case strings.HasPrefix(cmd, "SH"):
+		v, err := strconv.ParseUint(cmd[2:], 16, 32)
+		if err != nil || (len(cmd) != 5 && len(cmd) != 8 && len(cmd) != 10) {
+			return []string{"?"}
+		}
+		s.header = uint32(v)
+		if len(cmd) == 10 {
+			s.priority = byte(v >> 24)
+			s.header &= 0xffffff
+		}
+		return ok
+	case strings.HasPrefix(cmd, "CP"):
+		v, err := strconv.ParseUint(cmd[2:], 16, 8)
+		if err != nil {
+			return []string{"?"}
+		}
+		s.priority = byte(v)
+		return ok
+	case strings.HasPrefix(cmd, "CRA"):
+		if _, err := strconv.ParseUint("0"+cmd[3:], 16, 32); err != nil {
+			return []string{"?"}
+		}
+		s.receive = cmd[3:]
+		return ok
+	case strings.HasPrefix(cmd, "CV"), strings.HasPrefix(cmd, "ST"), strings.HasPrefix(cmd, "AT"),
+		cmd == "CSM0", cmd == "CSM1", cmd == "KW0", cmd == "KW1", cmd == "FE", cmd == "CEA",
+		cmd == "M0", cmd == "M1", cmd == "PC":
+		return ok
+	}
+	return []string{"?"}
+}
+
+func (s *session) setProtocol(arg string) []string {
+	auto := strings.HasPrefix(arg, "A")
+	p, err := strconv.ParseUint(strings.TrimPrefix(arg, "A"), 16, 8)
+	if err != nil || elm327.Protocol(p) > elm327.ProtocolUser2 {
+		return []string{"?"}
+	}
+	s.proto = elm327.Protocol(p)
+	s.auto = auto || s.proto == elm327.ProtocolAuto
+	s.found = false
+	return []string{"OK"}
+}
+
+// current will return the protocol the session is using, or ProtocolAuto if still searching
+func (s *session) current() elm327.Protocol {
+	if s.found {
+		return s.e.Protocol
+	}
+	return s.proto
+}
+
+// obd will send an OBD request to the backend and format the responses
+func (s *session) obd(cmd string) []string {
+	data, err := hex.DecodeString(cmd)
+	if err != nil || len(data) == 0 || len(data) > 7 {
+		return []string{"?"}
+	}
+
+	var lines []string
+	if !s.found {
+		if s.proto != s.e.Protocol && !s.auto {
+			if s.proto.IsCAN() {
+				return []string{"CAN ERROR"}
+			}
+			return []string{"BUS INIT: ...ERROR"}
+		}
+		if s.proto != s.e.Protocol {
+			lines = append(lines, "SEARCHING...")
+		}
+		s.found = true
+	}
+
+	if !s.caf && s.e.Protocol.IsCAN() {
+		// without formatting, the PCI byte is part of the request
+		if int(data[0]&0xf) != len(data)-1 {
+			return append(lines, "?")
+		}
+		data = data[1:]
+	}
+
+	res, err := s.e.Backend.RoundTrip(&obd2.Request{Mode: data[0], Args: data[1:], Address: s.address()})
+	if err != nil {
+		return append(lines, "CAN ERROR")
+	}
+	n := len(lines)
+	for _, m := range res.Messages {
+		if s.receive != "" && !strings.HasSuffix(fmt.Sprintf("%X", uint32(m.Source)), s.receive) {
+			continue
+		}
+		lines = append(lines, s.format(m)...)
+	}
+	if len(lines) == n {
+		lines = append(lines, "NO DATA")
+	}
+	return lines
+}
+
+// address will return the request address from the current header
+func (s *session) address() obd2.Address {
+	p := s.e.Protocol
+	switch {
+	case s.header == 0:
+		return obd2.AddressFunctional
+	case p.Is29Bit():
+		addr := uint32(s.priority)<<24 | s.header
+		if addr == 0x18db33f1 {
+			return obd2.AddressFunctional
+		}
+		return obd2.Address(addr)
+	case p.IsCAN():
+		if s.header == 0x7df {
+			return obd2.AddressFunctional
+		}
+		return obd2.Address(s.header & 0x7ff)
+	case s.header == 0x686af1, s.header == 0x616af1:
+		return obd2.AddressFunctional
+	}
+	return obd2.Address(s.header)
+}
+
+func (s *session) bytes(data []byte) string {
+	str := strings.ToUpper(hex.EncodeToString(data))
+	if !s.spaces || len(str) < 2 {
+		return str
+	}
+	parts := make([]string, 0, len(data))
+	for i := 0; i < len(str); i += 2 {
+		parts = append(parts, str[i:i+2])
+	}
+	return strings.Join(parts, " ")
+}
+
+func (s *session) join(parts ...string) string {
+	if s.spaces {
+		return strings.Join(parts, " ")
+	}
+	return strings.Join(parts, "")
+}
+
+// format will return the lines the device would display for m
+func (s *session) format(m obd2.Message) []string {
+	p := s.e.Protocol
+	if !p.IsCAN() {
+		if !s.headers {
+			return []string{s.bytes(m.Data)}
+		}
+		frame := append([]byte{0x48, 0x6b, byte(m.Source)}, m.Data...)
+		var sum byte
+		for _, b := range frame {
+			sum += b
+		}
+		return []string{s.bytes(append(frame, sum))}
+	}
+
+	var header string
+	if s.headers {
+		header = fmt.Sprintf("%03X", uint32(m.Source))
+		if p.Is29Bit() {
+			header = s.bytes([]byte{byte(m.Source >> 24), byte(m.Source >> 16), byte(m.Source >> 8), byte(m.Source)})
+		}
+	}
+	line := func(data []byte) string {
+		if header == "" {
+			return s.bytes(data)
+		}
+		return s.join(header, s.bytes(data))
+	}
+
+	if len(m.Data) <= 7 {
+		if !s.headers && s.caf {
+			return []string{line(m.Data)}
+		}
+		return []string{line(pad(append([]byte{byte(len(m.Data))}, m.Data...)))}
+	}
+
+	if !s.headers && s.caf {
+		lines := []string{fmt.Sprintf("%03X", len(m.Data))}
+		data := m.Data
+		for i := 0; len(data) > 0; i++ {
+			n := 7
+			if i == 0 {
+				n = 6
+			}
+			if n > len(data) {
+				n = len(data)
+			}
+			lines = append(lines, fmt.Sprintf("%X: %s", i&0xf, s.bytes(data[:n])))
+			data = data[n:]
+		}
+		return lines
+	}
+
+	lines := []string{line(append([]byte{0x10 | byte(len(m.Data)>>8), byte(len(m.Data))}, m.Data[:6]...))}
+	data := m.Data[6:]
+	for seq := byte(1); len(data) > 0; seq++ {
+		n := 7
+		if n > len(data) {
+			n = len(data)
+		}
+		lines = append(lines, line(pad(append([]byte{0x20 | seq&0xf}, data[:n]...))))
+		data = data[n:]
+	}
+	return lines
+}
+
+// pad will pad a CAN frame to 8 bytes
+func pad(data []byte) []byte {
+	for len(data) < 8 {
+		data = append(data, 0)
+	}
+	return data
+}
@@ -0,0 +1,124 @@
+package emulator
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mastercactapus/obd2"
+	"github.com/mastercactapus/obd2/elm327"
+	"github.com/mastercactapus/obd2/sim"
+)
+
+const testVIN = "1G1JC5444R7252367"
+
+// serve will start an emulator for a vehicle with an engine ECU that has a VIN and a transmission ECU,
+// and return the other end of the connection
+func serve(t *testing.T) net.Conn {
+	ecm := sim.NewECU(0x7e8)
+	ecm.VIN = testVIN
+	tcm := sim.NewECU(0x7e9)
+
+	client, server := net.Pipe()
+	go New(sim.NewVehicle(ecm, tcm)).Serve(server)
+	t.Cleanup(func() {
+		client.Close()
+		server.Close()
+	})
+	client.SetDeadline(time.Now().Add(5 * time.Second))
+	return client
+}
+
+func TestServe(t *testing.T) {
+	conn := serve(t)
+	r := bufio.NewReader(conn)
+
+	cases := []struct {
+		name string
+		send string
+		want string
+	}{
+		{"Reset", "ATZ", "ATZ\r\rELM327 v1.5\r\r>"},
+		{"EchoUpper", "ati", "ATI\rELM327 v1.5\r\r>"},
+		{"EchoOff", "ATE0", "ATE0\rOK\r\r>"},
+		{"NoEcho", "ATI", "ELM327 v1.5\r\r>"},
+		{"Search", "010D", "SEARCHING...\r41 0D 00\r41 0D 00\r\r>"},
+		{"Repeat", "", "41 0D 00\r41 0D 00\r\r>"},
+		{"Headers", "ATH1", "OK\r\r>"},
+		{"HeadersSingle", "010D", "7E8 03 41 0D 00 00 00 00 00\r7E9 03 41 0D 00 00 00 00 00\r\r>"},
+		{"HeadersMulti", "0902", "7E8 10 14 49 02 01 31 47 31\r7E8 21 4A 43 35 34 34 34 52\r7E8 22 37 32 35 32 33 36 37\r\r>"},
+		{"HeadersOff", "ATH0", "OK\r\r>"},
+		{"Segments", "0902", "014\r0: 49 02 01 31 47 31\r1: 4A 43 35 34 34 34 52\r2: 37 32 35 32 33 36 37\r\r>"},
+		{"EchoOn", "ATE1", "OK\r\r>"},
+		{"EchoRepeat", "", "\rOK\r\r>"},
+		{"Unknown", "ATXYZ", "ATXYZ\r?\r\r>"},
+	}
+	for _, c := range cases {
+		_, err := conn.Write([]byte(c.send + "\r"))
+		if err != nil {
+			t.Fatalf("%s: write: %v", c.name, err)
+		}
+		got, err := r.ReadString('>')
+		if err != nil {
+			t.Fatalf("%s: read: %v", c.name, err)
+		}
+		if got != c.want {
+			t.Errorf("%s: %q got %q; want %q", c.name, c.send, got, c.want)
+		}
+	}
+}
+
+func TestConnect(t *testing.T) {
+	for _, headers := range []bool{false, true} {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		d, err := elm327.Connect(ctx, serve(t), elm327.Config{Headers: headers})
+		if err != nil {
+			t.Fatalf("headers=%t: connect: %v", headers, err)
+		}
+		if v := d.Capabilities().Version; v != "ELM327 v1.5" {
+			t.Errorf("headers=%t: version %q; want %q", headers, v, "ELM327 v1.5")
+		}
+
+		// without headers, messages are numbered in the order they were received
+		sources := []obd2.Address{0, 1}
+		if headers {
+			sources = []obd2.Address{0x7e8, 0x7e9}
+		}
+
+		// the second request is sent as an empty line, repeating the first
+		for i := 0; i < 2; i++ {
+			res, err := d.RoundTrip(&obd2.Request{Mode: 0x01, Args: []byte{0x0d}})
+			if err != nil {
+				t.Fatalf("headers=%t: request %d: %v", headers, i, err)
+			}
+			var got []obd2.Address
+			for _, m := range res.Messages {
+				got = append(got, m.Source)
+				if !bytes.Equal(m.Data, []byte{0x41, 0x0d, 0x00}) {
+					t.Errorf("headers=%t: request %d: data % x; want 41 0d 00", headers, i, m.Data)
+				}
+			}
+			if !reflect.DeepEqual(got, sources) {
+				t.Errorf("headers=%t: request %d: sources %X; want %X", headers, i, got, sources)
+			}
+		}
+
+		// the VIN spans several CAN frames that must be put back together
+		res, err := d.RoundTrip(&obd2.Request{Mode: 0x09, Args: []byte{0x02}})
+		if err != nil {
+			t.Fatalf("headers=%t: VIN: %v", headers, err)
+		}
+		want := append([]byte{0x49, 0x02, 0x01}, testVIN...)
+		if len(res.Messages) != 1 || !bytes.Equal(res.Messages[0].Data, want) {
+			t.Errorf("headers=%t: VIN got %v; want % x", headers, res.Messages, want)
+		} else if headers && res.Messages[0].Source != 0x7e8 {
+			t.Errorf("headers=%t: VIN from %X; want 7E8", headers, res.Messages[0].Source)
+		}
+	}
+}
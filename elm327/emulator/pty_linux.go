@@ -0,0 +1,87 @@
+package emulator
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// PTY is a Linux pseudo-terminal. The emulator serves on the master side, while clients open the
+// device at Name() as if it were a serial port
+type PTY struct {
+	master *os.File
+	slave  *os.File
+}
+
+func ioctl(fd, req, arg uintptr) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, req, arg)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// OpenPTY will create a new pseudo-terminal in raw mode, so bytes pass through unmodified
+func OpenPTY() (*PTY, error) {
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	var n uint32
+	err = ioctl(master.Fd(), syscall.TIOCGPTN, uintptr(unsafe.Pointer(&n)))
+	if err != nil {
+		master.Close()
+		return nil, fmt.Errorf("get pty number: %w", err)
+	}
+	var unlock int32
+	err = ioctl(master.Fd(), syscall.TIOCSPTLCK, uintptr(unsafe.Pointer(&unlock)))
+	if err != nil {
+		master.Close()
+		return nil, fmt.Errorf("unlock pty: %w", err)
+	}
+
+	// the slave is kept open so reads on the master don't fail while no client is connected
+	slave, err := os.OpenFile(fmt.Sprintf("/dev/pts/%d", n), os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		master.Close()
+		return nil, err
+	}
+	var t syscall.Termios
+	err = ioctl(slave.Fd(), syscall.TCGETS, uintptr(unsafe.Pointer(&t)))
+	if err == nil {
+		t.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+		t.Oflag &^= syscall.OPOST
+		t.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+		t.Cflag &^= syscall.CSIZE | syscall.PARENB
+		t.Cflag |= syscall.CS8
+		t.Cc[syscall.VMIN] = 1
+		t.Cc[syscall.VTIME] = 0
+		err = ioctl(slave.Fd(), syscall.TCSETS, uintptr(unsafe.Pointer(&t)))
+	}
+	if err != nil {
+		master.Close()
+		slave.Close()
+		return nil, fmt.Errorf("set raw mode: %w", err)
+	}
+	return &PTY{master: master, slave: slave}, nil
+}
+
+// Name will return the path of the device clients should open
+func (p *PTY) Name() string {
+	return p.slave.Name()
+}
+
+func (p *PTY) Read(b []byte) (int, error) {
+	return p.master.Read(b)
+}
+
+func (p *PTY) Write(b []byte) (int, error) {
+	return p.master.Write(b)
+}
+
+// Close will close both sides of the pseudo-terminal
+func (p *PTY) Close() error {
+	p.slave.Close()
+	return p.master.Close()
+}
@@ -0,0 +1,112 @@
+package elm327
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrUnknownCommand means the device did not understand the command ("?")
+	ErrUnknownCommand = errors.New("invalid or unknown command")
+
+	// ErrNoData means no response was received from the vehicle for the request ("NO DATA"). Only SendAT returns it,
+	// RoundTrip reports NO DATA as a response with no messages so obd2.Client returns obd2.ErrNoResponse
+	ErrNoData = errors.New("no data")
+
+	// ErrCANError means the device had trouble sending or receiving on the CAN bus ("CAN ERROR").
+	// Usually the wrong protocol, or a wiring problem
+	ErrCANError = errors.New("CAN error")
+
+	// ErrBusInit means initializing the bus for the selected protocol failed ("BUS INIT: ...ERROR")
+	ErrBusInit = errors.New("bus init error")
+
+	// ErrBusBusy means there was too much activity on the bus to send the request ("BUS BUSY")
+	ErrBusBusy = errors.New("bus busy")
+
+	// ErrBusError means an invalid signal was detected on the bus ("BUS ERROR")
+	ErrBusError = errors.New("bus error")
+
+	// ErrUnableToConnect means the device could not find a supported protocol ("UNABLE TO CONNECT")
+	ErrUnableToConnect = errors.New("unable to connect")
+
+	// ErrBufferFull means the device's internal buffer overflowed before the data could be sent ("BUFFER FULL")
+	ErrBufferFull = errors.New("buffer full")
+
+	// ErrStopped means the operation was interrupted by a character sent to the device ("STOPPED")
+	ErrStopped = errors.New("stopped")
+
+	// ErrDataError means a response was received but had a bad checksum or format ("DATA ERROR" or "<DATA ERROR")
+	ErrDataError = errors.New("data error")
+
+	// ErrFBError means there was a problem with the feedback of the bus output ("FB ERROR").
+	// Usually a wiring problem
+	ErrFBError = errors.New("feedback error")
+
+	// ErrLVReset means the device reset itself due to low voltage ("LV RESET"). Settings will have been lost
+	ErrLVReset = errors.New("low voltage reset")
+
+	// ErrRXError means a CAN message was received with an error ("<RX ERROR")
+	ErrRXError = errors.New("receive error")
+
+	// ErrActivityAlert means the device saw no bus activity and is about to go to low power mode ("ACT ALERT")
+	ErrActivityAlert = errors.New("activity alert")
+
+	// ErrIncompleteResponse means a multi-frame response was missing one or more frames
+	ErrIncompleteResponse = errors.New("incomplete response")
+
+	// ErrLowPowerAlert means the device is about to switch to low power mode ("LP ALERT")
+	ErrLowPowerAlert = errors.New("low power alert")
+)
+
+// InternalError is returned when the device reports an internal error code ("ERRxx")
+type InternalError struct {
+	Code string
+}
+
+func (e InternalError) Error() string {
+	return "internal error " + e.Code
+}
+
+// responseError will check the lines of a response for any error messages. A nil error means
+// the response did not contain any
+func responseError(response string) error {
+	for _, line := range strings.FieldsFunc(response, func(r rune) bool { return r == '\r' || r == '\n' }) {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "?":
+			return ErrUnknownCommand
+		case line == "NO DATA":
+			return ErrNoData
+		case line == "CAN ERROR":
+			return ErrCANError
+		case strings.HasPrefix(line, "BUS INIT:") && strings.HasSuffix(line, "ERROR"):
+			return fmt.Errorf("%w: %s", ErrBusInit, line)
+		case line == "BUS BUSY":
+			return ErrBusBusy
+		case line == "BUS ERROR":
+			return ErrBusError
+		case line == "UNABLE TO CONNECT":
+			return ErrUnableToConnect
+		case line == "BUFFER FULL":
+			return ErrBufferFull
+		case line == "STOPPED":
+			return ErrStopped
+		case line == "DATA ERROR", strings.HasSuffix(line, "<DATA ERROR"):
+			return ErrDataError
+		case line == "FB ERROR":
+			return ErrFBError
+		case line == "LV RESET":
+			return ErrLVReset
+		case strings.HasSuffix(line, "<RX ERROR"):
+			return ErrRXError
+		case line == "ACT ALERT":
+			return ErrActivityAlert
+		case line == "LP ALERT":
+			return ErrLowPowerAlert
+		case len(line) == 5 && strings.HasPrefix(line, "ERR"):
+			return InternalError{Code: line[3:]}
+		}
+	}
+	return nil
+}
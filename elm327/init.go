@@ -0,0 +1,136 @@
+package elm327
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrNotSupported is returned when a command is known not to be supported by the connected device
+var ErrNotSupported = errors.New("command not supported by device")
+
+// Config controls how the device is initialized
+type Config struct {
+	// Headers will enable headers in OBD responses (ATH1)
+	Headers bool
+}
+
+// Capabilities describes the connected device, and which commands it actually accepts. Many clones
+// report a version they do not fully implement, so commands are probed rather than inferred from Version
+type Capabilities struct {
+	// Version is the version string reported by ATI (e.g. "ELM327 v1.5")
+	Version string
+
+	// Description is the device description reported by AT@1
+	Description string
+
+	// commands holds the probe result for each command
+	commands map[string]bool
+}
+
+// Supports will return false if cmd (without the AT prefix, e.g. "CAF") was rejected by the device
+// during probing. Commands that were not probed are assumed to be supported
+func (c *Capabilities) Supports(cmd string) bool {
+	ok, probed := c.commands[cmd]
+	return ok || !probed
+}
+
+// probes lists each probed command with a harmless invocation of it. Where the command changes a setting,
+// the invocation sets it to the power-on default
+var probes = []struct {
+	name, cmd string
+}{
+	{"@2", "@2"},
+	{"AMC", "AMC"},
+	{"AT", "AT1"},
+	{"CAF", "CAF1"},
+	{"CEA", "CEA"},
+	{"CRA", "CRA"},
+	{"CSM", "CSM1"},
+	{"DPN", "DPN"},
+	{"FE", "FE"},
+	{"IGN", "IGN"},
+	{"KW", "KW1"},
+	{"RV", "RV"},
+	{"ST", "ST32"},
+}
+
+// Connect will create a new ELM327 from rw and initialize it with cfg
+func Connect(ctx context.Context, rw io.ReadWriter, cfg Config) (*ELM327, error) {
+	c := New(rw)
+	err := c.Init(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Init will reset the device, turn off echo, linefeeds and spaces, set headers according to cfg,
+// and probe for supported commands
+func (c *ELM327) Init(ctx context.Context, cfg Config) error {
+	_, err := c.SendATContext(ctx, "Z")
+	c.resetState()
+	if err != nil {
+		return fmt.Errorf("reset: %w", err)
+	}
+	for _, cmd := range []string{"E0", "L0"} {
+		_, err = c.SendATContext(ctx, cmd)
+		if err != nil {
+			return fmt.Errorf("AT%s: %w", cmd, err)
+		}
+	}
+
+	caps := &Capabilities{commands: make(map[string]bool, len(probes)+1)}
+
+	// spaces can't be turned off on older versions, but responses are parsed either way
+	_, err = c.SendATContext(ctx, "S0")
+	if err != nil && !errors.Is(err, ErrUnknownCommand) {
+		return fmt.Errorf("ATS0: %w", err)
+	}
+	caps.commands["S"] = err == nil
+
+	h := "H0"
+	if cfg.Headers {
+		h = "H1"
+	}
+	_, err = c.SendATContext(ctx, h)
+	if err != nil {
+		return fmt.Errorf("AT%s: %w", h, err)
+	}
+	c.headers = cfg.Headers
+
+	caps.Version, err = c.SendATContext(ctx, "I")
+	if err != nil {
+		return fmt.Errorf("ATI: %w", err)
+	}
+	caps.Description, err = c.SendATContext(ctx, "@1")
+	if err != nil && !errors.Is(err, ErrUnknownCommand) {
+		return fmt.Errorf("AT@1: %w", err)
+	}
+	caps.commands["@1"] = err == nil
+
+	for _, p := range probes {
+		_, err = c.SendATContext(ctx, p.cmd)
+		if err != nil && !errors.Is(err, ErrUnknownCommand) {
+			return fmt.Errorf("AT%s: %w", p.cmd, err)
+		}
+		caps.commands[p.name] = err == nil
+	}
+
+	c.caps = caps
+	return nil
+}
+
+// Capabilities will return the capabilities detected by Init, or nil if the device has not been initialized
+func (c *ELM327) Capabilities() *Capabilities {
+	return c.caps
+}
+
+// require will return ErrNotSupported if the device is known not to support cmd
+func (c *ELM327) require(cmd string) error {
+	if c.caps != nil && !c.caps.Supports(cmd) {
+		return fmt.Errorf("AT%s: %w", cmd, ErrNotSupported)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package elm327
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Protocol is an OBD protocol, as numbered by the device
+type Protocol byte
+
+const (
+	// ProtocolAuto will have the device search for a protocol
+	ProtocolAuto Protocol = 0x0
+
+	// ProtocolJ1850PWM is SAE J1850 PWM (41.6 kbaud)
+	ProtocolJ1850PWM Protocol = 0x1
+
+	// ProtocolJ1850VPW is SAE J1850 VPW (10.4 kbaud)
+	ProtocolJ1850VPW Protocol = 0x2
+
+	// ProtocolISO9141 is ISO 9141-2 (5 baud init, 10.4 kbaud)
+	ProtocolISO9141 Protocol = 0x3
+
+	// ProtocolKWPSlow is ISO 14230-4 KWP (5 baud init, 10.4 kbaud)
+	ProtocolKWPSlow Protocol = 0x4
+
+	// ProtocolKWPFast is ISO 14230-4 KWP (fast init, 10.4 kbaud)
+	ProtocolKWPFast Protocol = 0x5
+
+	// ProtocolCAN11At500 is ISO 15765-4 CAN (11 bit ID, 500 kbaud)
+	ProtocolCAN11At500 Protocol = 0x6
+
+	// ProtocolCAN29At500 is ISO 15765-4 CAN (29 bit ID, 500 kbaud)
+	ProtocolCAN29At500 Protocol = 0x7
+
+	// ProtocolCAN11At250 is ISO 15765-4 CAN (11 bit ID, 250 kbaud)
+	ProtocolCAN11At250 Protocol = 0x8
+
+	// ProtocolCAN29At250 is ISO 15765-4 CAN (29 bit ID, 250 kbaud)
+	ProtocolCAN29At250 Protocol = 0x9
+
+	// ProtocolJ1939 is SAE J1939 CAN (29 bit ID, 250 kbaud)
+	ProtocolJ1939 Protocol = 0xA
+
+	// ProtocolUser1 is USER1 CAN (11 bit ID, 125 kbaud by default)
+	ProtocolUser1 Protocol = 0xB
+
+	// ProtocolUser2 is USER2 CAN (11 bit ID, 50 kbaud by default)
+	ProtocolUser2 Protocol = 0xC
+)
+
+var protocolNames = [...]string{
+	ProtocolAuto:       "Automatic",
+	ProtocolJ1850PWM:   "SAE J1850 PWM",
+	ProtocolJ1850VPW:   "SAE J1850 VPW",
+	ProtocolISO9141:    "ISO 9141-2",
+	ProtocolKWPSlow:    "ISO 14230-4 (KWP 5BAUD)",
+	ProtocolKWPFast:    "ISO 14230-4 (KWP FAST)",
+	ProtocolCAN11At500: "ISO 15765-4 (CAN 11/500)",
+	ProtocolCAN29At500: "ISO 15765-4 (CAN 29/500)",
+	ProtocolCAN11At250: "ISO 15765-4 (CAN 11/250)",
+	ProtocolCAN29At250: "ISO 15765-4 (CAN 29/250)",
+	ProtocolJ1939:      "SAE J1939 (CAN 29/250)",
+	ProtocolUser1:      "USER1 (CAN 11/125)",
+	ProtocolUser2:      "USER2 (CAN 11/50)",
+}
+
+func (p Protocol) String() string {
+	if int(p) < len(protocolNames) {
+		return protocolNames[p]
+	}
+	return "Protocol(" + strconv.Itoa(int(p)) + ")"
+}
+
+// IsCAN will return true if p is a CAN protocol
+func (p Protocol) IsCAN() bool {
+	return p >= ProtocolCAN11At500 && p <= ProtocolUser2
+}
+
+// Is29Bit will return true if p is a CAN protocol using 29 bit IDs
+func (p Protocol) Is29Bit() bool {
+	return p == ProtocolCAN29At500 || p == ProtocolCAN29At250 || p == ProtocolJ1939
+}
+
+// protocolArg will format p for ATSP/ATTP, with the 'A' prefix if auto is set
+func protocolArg(p Protocol, auto bool) (string, error) {
+	if p > ProtocolUser2 {
+		return "", fmt.Errorf("invalid protocol %d", p)
+	}
+	if auto && p != ProtocolAuto {
+		return fmt.Sprintf("A%X", byte(p)), nil
+	}
+	return fmt.Sprintf("%X", byte(p)), nil
+}
+
+// SetProtocol will set the protocol and save it as the default. If auto is set, the device will
+// search for another protocol if p fails
+func (c *ELM327) SetProtocol(p Protocol, auto bool) error {
+	arg, err := protocolArg(p, auto)
+	if err != nil {
+		return err
+	}
+	_, err = c.SendAT("SP" + arg)
+	c.setProtocol(p, auto, err)
+	return err
+}
+
+// TryProtocol will try the protocol without saving it as the default. If auto is set, the device will
+// search for another protocol if p fails
+func (c *ELM327) TryProtocol(p Protocol, auto bool) error {
+	arg, err := protocolArg(p, auto)
+	if err != nil {
+		return err
+	}
+	_, err = c.SendAT("TP" + arg)
+	c.setProtocol(p, auto, err)
+	return err
+}
+
+// setProtocol will remember p as the active protocol if it was set without searching. Otherwise
+// the protocol is unknown until the device reports it
+func (c *ELM327) setProtocol(p Protocol, auto bool, err error) {
+	c.proto = nil
+	if err == nil && !auto && p != ProtocolAuto {
+		c.proto = &p
+	}
+}
+
+// Protocol will return the current protocol, and whether it was selected automatically
+func (c *ELM327) Protocol() (Protocol, bool, error) {
+	return c.readProtocol(context.Background())
+}
+
+// readProtocol will ask the device for the current protocol. Devices without ATDPN are asked for
+// the description (ATDP) instead
+func (c *ELM327) readProtocol(ctx context.Context) (Protocol, bool, error) {
+	if c.require("DPN") != nil {
+		res, err := c.SendATContext(ctx, "DP")
+		if err != nil {
+			return 0, false, err
+		}
+		return parseProtocolDescription(res)
+	}
+	res, err := c.SendATContext(ctx, "DPN")
+	if err != nil {
+		return 0, false, err
+	}
+	auto := strings.HasPrefix(res, "A")
+	p, err := strconv.ParseUint(strings.TrimPrefix(res, "A"), 16, 8)
+	if err != nil || Protocol(p) > ProtocolUser2 {
+		return 0, false, fmt.Errorf("invalid protocol number '%s'", res)
+	}
+	return Protocol(p), auto, nil
+}
+
+// parseProtocolDescription will parse the response of ATDP (e.g. "AUTO, ISO 15765-4 (CAN 11/500)")
+func parseProtocolDescription(res string) (Protocol, bool, error) {
+	name := strings.TrimPrefix(res, "AUTO, ")
+	auto := len(name) < len(res)
+	if name == "AUTO" {
+		return ProtocolAuto, true, nil
+	}
+	for p, n := range protocolNames {
+		if p != int(ProtocolAuto) && strings.EqualFold(n, name) {
+			return Protocol(p), auto, nil
+		}
+	}
+	return 0, false, fmt.Errorf("unknown protocol '%s'", res)
+}
+
+// ProtocolDescription will return the description of the current protocol, as reported by the device
+// (e.g. "AUTO, ISO 15765-4 (CAN 11/500)")
+func (c *ELM327) ProtocolDescription() (string, error) {
+	return c.SendAT("DP")
+}
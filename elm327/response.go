@@ -0,0 +1,267 @@
+package elm327
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mastercactapus/obd2"
+)
+
+// splitLines will split a response into individual lines, dropping blank and informational ones
+func splitLines(response string) []string {
+	var lines []string
+	for _, line := range strings.FieldsFunc(response, func(r rune) bool { return r == '\r' || r == '\n' }) {
+		line = strings.TrimSpace(line)
+		if line == "" || line == "SEARCHING..." || strings.HasPrefix(line, "BUS INIT:") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// parseResponse will parse the lines of an OBD response into one message per ECU, reassembling
+// multi-frame CAN responses. If headers is set, the source address is read from the header according to the protocol p
+func parseResponse(lines []string, headers bool, p Protocol) ([]obd2.Message, error) {
+	if !headers {
+		return parseSegments(lines)
+	}
+
+	var msgs []obd2.Message
+	var a assembler
+	for _, line := range lines {
+		src, data, err := parseHeader(strings.Replace(line, " ", "", -1), p)
+		if err != nil {
+			return nil, err
+		}
+		if !p.IsCAN() {
+			msgs = append(msgs, obd2.Message{Source: src, Data: data})
+			continue
+		}
+		err = a.addFrame(src, data)
+		if err != nil {
+			return nil, fmt.Errorf("bad response line '%s': %w", line, err)
+		}
+	}
+	if !p.IsCAN() {
+		return msgs, nil
+	}
+	return a.messages()
+}
+
+// parseHeader will split a line (with spaces removed) into the source address and data. For CAN the data
+// still starts with the frame's PCI byte(s). For other protocols the checksum is removed
+func parseHeader(line string, p Protocol) (obd2.Address, []byte, error) {
+	idLen := 6
+	switch {
+	case p.Is29Bit():
+		idLen = 8
+	case p.IsCAN():
+		idLen = 3
+	}
+	if len(line) < idLen {
+		return 0, nil, fmt.Errorf("bad response line '%s': too short", line)
+	}
+	id, err := strconv.ParseUint(line[:idLen], 16, 32)
+	if err != nil {
+		return 0, nil, fmt.Errorf("bad response header '%s': %v", line[:idLen], err)
+	}
+	data, err := hex.DecodeString(line[idLen:])
+	if err != nil {
+		return 0, nil, fmt.Errorf("bad response line '%s': %v", line, err)
+	}
+	if p.IsCAN() {
+		return obd2.Address(id), data, nil
+	}
+
+	// priority, target and source bytes, then the data and a checksum
+	if len(data) < 1 {
+		return 0, nil, fmt.Errorf("bad response line '%s': too short", line)
+	}
+	return obd2.Address(id & 0xff), data[:len(data)-1], nil
+}
+
+// parseSegments will parse a response with headers off. Multi-frame CAN responses are formatted by the device
+// as a line with the total byte count, followed by lines of numbered segments ("0: 49 02 01 ..."). Without
+// headers the source is unknown, so messages are numbered in the order they started instead
+func parseSegments(lines []string) ([]obd2.Message, error) {
+	var a assembler
+	for _, line := range lines {
+		if len(line) == 3 {
+			n, err := strconv.ParseUint(line, 16, 12)
+			if err != nil {
+				return nil, fmt.Errorf("bad response line '%s': %v", line, err)
+			}
+			a.start(obd2.Address(len(a.partials)), int(n))
+			continue
+		}
+
+		seg := -1
+		if i := strings.IndexByte(line, ':'); i != -1 {
+			n, err := strconv.ParseUint(strings.TrimSpace(line[:i]), 16, 4)
+			if err != nil {
+				return nil, fmt.Errorf("bad response line '%s': %v", line, err)
+			}
+			seg = int(n)
+			line = line[i+1:]
+		}
+		data, err := hex.DecodeString(strings.Replace(line, " ", "", -1))
+		if err != nil {
+			return nil, fmt.Errorf("bad response line '%s': %v", line, err)
+		}
+		if seg == -1 {
+			a.single(obd2.Address(len(a.partials)), data)
+			continue
+		}
+		err = a.addSegment(seg, data)
+		if err != nil {
+			return nil, fmt.Errorf("bad response line '%s': %w", line, err)
+		}
+	}
+
+	return a.messages()
+}
+
+// partial is a message that is being reassembled
+type partial struct {
+	source obd2.Address
+	length int
+
+	// segments holds each consecutive segment of the message by its position. The first frame
+	// of a message is at position 0
+	segments map[int][]byte
+}
+
+// index will convert a 4 bit sequence number to a position in the message, using
+// the number of segments received so far to place it after any wrap-around
+func (p *partial) index(seq int) int {
+	n := len(p.segments)
+	idx := n&^0xf | seq
+	switch {
+	case idx-n > 8:
+		idx -= 16
+	case n-idx > 8:
+		idx += 16
+	}
+	return idx
+}
+
+func (p *partial) complete() bool {
+	size := 0
+	for _, s := range p.segments {
+		size += len(s)
+	}
+	return size >= p.length
+}
+
+func (p *partial) data() []byte {
+	data := make([]byte, 0, p.length)
+	for i := 0; len(data) < p.length; i++ {
+		s, ok := p.segments[i]
+		if !ok {
+			return nil
+		}
+		data = append(data, s...)
+	}
+	return data[:p.length]
+}
+
+// assembler collects frames from any number of ECUs and reassembles them into messages
+type assembler struct {
+	partials []*partial
+}
+
+func (a *assembler) start(src obd2.Address, length int) *partial {
+	p := &partial{source: src, length: length, segments: make(map[int][]byte)}
+	a.partials = append(a.partials, p)
+	return p
+}
+
+func (a *assembler) single(src obd2.Address, data []byte) {
+	p := a.start(src, len(data))
+	p.segments[0] = data
+}
+
+// addSegment will add a numbered segment (headers off) to the oldest incomplete message that doesn't already have it
+func (a *assembler) addSegment(seq int, data []byte) error {
+	for _, p := range a.partials {
+		if p.complete() {
+			continue
+		}
+		idx := p.index(seq)
+		if _, ok := p.segments[idx]; ok || idx < 0 {
+			continue
+		}
+		p.segments[idx] = data
+		return nil
+	}
+	return fmt.Errorf("%w: segment %X does not belong to any message", ErrIncompleteResponse, seq)
+}
+
+// addFrame will add an ISO-TP frame (headers on) from src
+func (a *assembler) addFrame(src obd2.Address, data []byte) error {
+	if len(data) < 1 {
+		return fmt.Errorf("empty frame from %X", src)
+	}
+	switch data[0] >> 4 {
+	case 0: // single frame
+		n := int(data[0] & 0xf)
+		if len(data) < n+1 {
+			return fmt.Errorf("single frame from %X: expected %d bytes", src, n)
+		}
+		a.single(src, data[1:n+1])
+	case 1: // first frame
+		if len(data) < 2 {
+			return fmt.Errorf("first frame from %X: too short", src)
+		}
+		p := a.start(src, int(data[0]&0xf)<<8|int(data[1]))
+		p.segments[0] = data[2:]
+	case 2: // consecutive frame
+		p := a.find(src)
+		if p == nil {
+			return fmt.Errorf("%w: consecutive frame from %X without a first frame", ErrIncompleteResponse, src)
+		}
+		idx := p.index(int(data[0] & 0xf))
+		if _, ok := p.segments[idx]; ok || idx < 1 {
+			return fmt.Errorf("duplicate consecutive frame %X from %X", data[0]&0xf, src)
+		}
+		p.segments[idx] = data[1:]
+	default:
+		return fmt.Errorf("unexpected frame type %X from %X", data[0]>>4, src)
+	}
+	return nil
+}
+
+// find will return the latest incomplete message from src
+func (a *assembler) find(src obd2.Address) *partial {
+	for i := len(a.partials) - 1; i >= 0; i-- {
+		p := a.partials[i]
+		if p.source == src && !p.complete() {
+			return p
+		}
+	}
+	return nil
+}
+
+// messages will return the reassembled messages, in the order they were started
+func (a *assembler) messages() ([]obd2.Message, error) {
+	msgs := make([]obd2.Message, 0, len(a.partials))
+	for _, p := range a.partials {
+		data := p.data()
+		if data == nil {
+			return nil, fmt.Errorf("%w: message from %X has %d of %d segments", ErrIncompleteResponse, p.source, len(p.segments), segmentCount(p.length))
+		}
+		msgs = append(msgs, obd2.Message{Source: p.source, Data: data})
+	}
+	return msgs, nil
+}
+
+// segmentCount will return the number of segments needed for a multi-frame message of length bytes
+func segmentCount(length int) int {
+	if length <= 6 {
+		return 1
+	}
+	return 1 + (length-6+6)/7
+}
@@ -0,0 +1,231 @@
+package elm327
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/mastercactapus/obd2"
+)
+
+// payload will return a mode 9 style response of n bytes
+func payload(n int, seed byte) []byte {
+	data := make([]byte, n)
+	data[0] = 0x49
+	for i := 1; i < n; i++ {
+		data[i] = seed + byte(i)
+	}
+	return data
+}
+
+// canFrames will format data as ISO-TP frames with headers on, padding the last frame to 8 bytes
+func canFrames(header string, data []byte) []string {
+	pad := func(f []byte) string {
+		for len(f) < 8 {
+			f = append(f, 0x00)
+		}
+		return header + fmt.Sprintf(" % X", f)
+	}
+	if len(data) <= 7 {
+		return []string{pad(append([]byte{byte(len(data))}, data...))}
+	}
+	lines := []string{pad(append([]byte{0x10 | byte(len(data)>>8), byte(len(data))}, data[:6]...))}
+	for i, seq := 6, 1; i < len(data); i, seq = i+7, seq+1 {
+		end := i + 7
+		if end > len(data) {
+			end = len(data)
+		}
+		lines = append(lines, pad(append([]byte{0x20 | byte(seq&0xf)}, data[i:end]...)))
+	}
+	return lines
+}
+
+// segmentLines will format data the way the device does with headers off
+func segmentLines(data []byte) []string {
+	if len(data) <= 7 {
+		return []string{fmt.Sprintf("% X", data)}
+	}
+	lines := []string{fmt.Sprintf("%03X", len(data)), fmt.Sprintf("0: % X", data[:6])}
+	for i, seq := 6, 1; i < len(data); i, seq = i+7, seq+1 {
+		end := i + 7
+		if end > len(data) {
+			end = len(data)
+		}
+		lines = append(lines, fmt.Sprintf("%X: % X", seq&0xf, data[i:end]))
+	}
+	return lines
+}
+
+// interleave will alternate the lines of a and b
+func interleave(a, b []string) []string {
+	var lines []string
+	for i := 0; i < len(a) || i < len(b); i++ {
+		if i < len(a) {
+			lines = append(lines, a[i])
+		}
+		if i < len(b) {
+			lines = append(lines, b[i])
+		}
+	}
+	return lines
+}
+
+func without(lines []string, i int) []string {
+	return append(append([]string(nil), lines[:i]...), lines[i+1:]...)
+}
+
+func withCopy(lines []string, i int) []string {
+	return append(append(append([]string(nil), lines[:i+1]...), lines[i]), lines[i+1:]...)
+}
+
+func TestParseResponse(t *testing.T) {
+	ecm := payload(20, 0x10)
+	tcm := payload(20, 0x40)
+	long := payload(125, 0x00)
+
+	cases := []struct {
+		name    string
+		lines   []string
+		headers bool
+		p       Protocol
+		want    []obd2.Message
+		err     error
+	}{
+		{
+			name:  "HeadersOff/Single",
+			lines: []string{"41 0C 0B B8"},
+			want:  []obd2.Message{{Source: 0, Data: []byte{0x41, 0x0c, 0x0b, 0xb8}}},
+		},
+		{
+			name:  "HeadersOff/TwoSingle",
+			lines: []string{"41 0D 00", "41 0D 05"},
+			want:  []obd2.Message{{Source: 0, Data: []byte{0x41, 0x0d, 0x00}}, {Source: 1, Data: []byte{0x41, 0x0d, 0x05}}},
+		},
+		{
+			name:  "HeadersOff/BackToBack",
+			lines: append(segmentLines(ecm), segmentLines(tcm)...),
+			want:  []obd2.Message{{Source: 0, Data: ecm}, {Source: 1, Data: tcm}},
+		},
+		{
+			name:  "HeadersOff/Interleaved",
+			lines: interleave(segmentLines(ecm), segmentLines(tcm)),
+			want:  []obd2.Message{{Source: 0, Data: ecm}, {Source: 1, Data: tcm}},
+		},
+		{
+			name:  "HeadersOff/MultiAndSingle",
+			lines: append(segmentLines(ecm), "49 02 01 00"),
+			want:  []obd2.Message{{Source: 0, Data: ecm}, {Source: 1, Data: []byte{0x49, 0x02, 0x01, 0x00}}},
+		},
+		{
+			name:  "HeadersOff/Wrap",
+			lines: segmentLines(long),
+			want:  []obd2.Message{{Source: 0, Data: long}},
+		},
+		{
+			name:  "HeadersOff/WrapBackToBack",
+			lines: append(segmentLines(long), segmentLines(ecm)...),
+			want:  []obd2.Message{{Source: 0, Data: long}, {Source: 1, Data: ecm}},
+		},
+		{
+			name:  "HeadersOff/Missing",
+			lines: without(segmentLines(ecm), 2),
+			err:   ErrIncompleteResponse,
+		},
+		{
+			name:  "HeadersOff/Duplicate",
+			lines: withCopy(segmentLines(ecm), 2),
+			err:   ErrIncompleteResponse,
+		},
+		{
+			name:    "CAN11/TwoSingle",
+			lines:   append(canFrames("7E8", []byte{0x41, 0x0d, 0x00}), canFrames("7E9", []byte{0x41, 0x0d, 0x05})...),
+			headers: true,
+			p:       ProtocolCAN11At500,
+			want:    []obd2.Message{{Source: 0x7e8, Data: []byte{0x41, 0x0d, 0x00}}, {Source: 0x7e9, Data: []byte{0x41, 0x0d, 0x05}}},
+		},
+		{
+			name:    "CAN11/BackToBack",
+			lines:   append(canFrames("7E8", ecm), canFrames("7E9", tcm)...),
+			headers: true,
+			p:       ProtocolCAN11At500,
+			want:    []obd2.Message{{Source: 0x7e8, Data: ecm}, {Source: 0x7e9, Data: tcm}},
+		},
+		{
+			name:    "CAN11/Interleaved",
+			lines:   interleave(canFrames("7E9", tcm), canFrames("7E8", ecm)),
+			headers: true,
+			p:       ProtocolCAN11At500,
+			want:    []obd2.Message{{Source: 0x7e9, Data: tcm}, {Source: 0x7e8, Data: ecm}},
+		},
+		{
+			name:    "CAN11/Wrap",
+			lines:   interleave(canFrames("7E8", long), canFrames("7E9", tcm)),
+			headers: true,
+			p:       ProtocolCAN11At500,
+			want:    []obd2.Message{{Source: 0x7e8, Data: long}, {Source: 0x7e9, Data: tcm}},
+		},
+		{
+			name:    "CAN11/Missing",
+			lines:   without(canFrames("7E8", ecm), 1),
+			headers: true,
+			p:       ProtocolCAN11At500,
+			err:     ErrIncompleteResponse,
+		},
+		{
+			name:    "CAN11/MissingWrap",
+			lines:   without(canFrames("7E8", long), 16),
+			headers: true,
+			p:       ProtocolCAN11At500,
+			err:     ErrIncompleteResponse,
+		},
+		{
+			name:    "CAN11/Duplicate",
+			lines:   withCopy(canFrames("7E8", long), 1),
+			headers: true,
+			p:       ProtocolCAN11At500,
+			err:     errors.New("duplicate"),
+		},
+		{
+			name:    "CAN11/NoFirstFrame",
+			lines:   canFrames("7E8", ecm)[1:],
+			headers: true,
+			p:       ProtocolCAN11At500,
+			err:     ErrIncompleteResponse,
+		},
+		{
+			name:    "CAN29/Interleaved",
+			lines:   interleave(canFrames("18DAF110", ecm), canFrames("18DAF118", tcm)),
+			headers: true,
+			p:       ProtocolCAN29At500,
+			want:    []obd2.Message{{Source: 0x18daf110, Data: ecm}, {Source: 0x18daf118, Data: tcm}},
+		},
+		{
+			name:    "J1850/Single",
+			lines:   []string{"48 6B 10 41 0C 0B B8 4E", "48 6B 18 41 0C 0B B8 56"},
+			headers: true,
+			p:       ProtocolJ1850PWM,
+			want:    []obd2.Message{{Source: 0x10, Data: []byte{0x41, 0x0c, 0x0b, 0xb8}}, {Source: 0x18, Data: []byte{0x41, 0x0c, 0x0b, 0xb8}}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			msgs, err := parseResponse(c.lines, c.headers, c.p)
+			if c.err != nil {
+				if err == nil {
+					t.Fatalf("got %v; want error", msgs)
+				}
+				if errors.Is(c.err, ErrIncompleteResponse) && !errors.Is(err, ErrIncompleteResponse) {
+					t.Fatalf("got error %v; want %v", err, c.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(msgs, c.want) {
+				t.Errorf("got %x; want %x", msgs, c.want)
+			}
+		})
+	}
+}
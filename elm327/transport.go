@@ -0,0 +1,129 @@
+package elm327
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mastercactapus/obd2"
+)
+
+// RoundTrip will send an OBD request to the vehicle and return the response data. It satisfies the obd2.Transport interface.
+// If no ECU answers ("NO DATA") the response has no messages, like other transports, instead of returning ErrNoData
+func (c *ELM327) RoundTrip(req *obd2.Request) (*obd2.Response, error) {
+	return c.RoundTripContext(context.Background(), req)
+}
+
+// RoundTripContext is like RoundTrip, but will give up waiting for the response when ctx is done.
+// It satisfies the obd2.ContextTransport interface
+func (c *ELM327) RoundTripContext(ctx context.Context, req *obd2.Request) (*obd2.Response, error) {
+	err := c.setTarget(ctx, req.Address)
+	if err != nil {
+		return nil, err
+	}
+	cmd := strings.ToUpper(hex.EncodeToString(append([]byte{req.Mode}, req.Args...)))
+	response, err := c.send(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	err = responseError(response)
+	if err == ErrNoData {
+		return &obd2.Response{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var p Protocol
+	if c.headers {
+		// the protocol may have only just been found by this request
+		p, err = c.currentProtocol(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	msgs, err := parseResponse(splitLines(response), c.headers, p)
+	if err != nil {
+		return nil, err
+	}
+	return &obd2.Response{Messages: msgs}, nil
+}
+
+// SetHeaders will turn headers on or off (ATH1/ATH0). Headers are needed to tell which ECU sent each message
+func (c *ELM327) SetHeaders(on bool) error {
+	cmd := "H0"
+	if on {
+		cmd = "H1"
+	}
+	_, err := c.SendAT(cmd)
+	if err != nil {
+		return err
+	}
+	c.headers = on
+	return nil
+}
+
+// currentProtocol will return the active protocol, asking the device if it isn't already known.
+// ProtocolAuto is returned if the device has not found one yet
+func (c *ELM327) currentProtocol(ctx context.Context) (Protocol, error) {
+	if c.proto != nil {
+		return *c.proto, nil
+	}
+	p, _, err := c.readProtocol(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if p != ProtocolAuto {
+		c.proto = &p
+	}
+	return p, nil
+}
+
+// setTarget will set the header and receive address so requests go to addr. The default header
+// and receive filter are restored for obd2.AddressFunctional
+func (c *ELM327) setTarget(ctx context.Context, addr obd2.Address) error {
+	if addr == c.target {
+		return nil
+	}
+	p, err := c.currentProtocol(ctx)
+	if err != nil {
+		return err
+	}
+	if p == ProtocolAuto {
+		return errors.New("protocol must be known before addressing an ECU; set one or send a request first")
+	}
+
+	var cmds []string
+	switch {
+	case addr == obd2.AddressFunctional && p.Is29Bit():
+		cmds = []string{"CP18", "SHDB33F1", "CRA"}
+	case addr == obd2.AddressFunctional && p.IsCAN():
+		cmds = []string{"SH7DF", "CRA"}
+	case addr == obd2.AddressFunctional && p == ProtocolJ1850PWM:
+		cmds = []string{"SH616AF1"}
+	case addr == obd2.AddressFunctional:
+		cmds = []string{"SH686AF1"}
+	case p.Is29Bit():
+		// physical responses swap the target and source bytes
+		res := addr&0xffff0000 | (addr&0xff)<<8 | (addr>>8)&0xff
+		cmds = []string{fmt.Sprintf("CP%02X", byte(addr>>24)), fmt.Sprintf("SH%06X", addr&0xffffff), fmt.Sprintf("CRA%08X", res)}
+	case p.IsCAN():
+		cmds = []string{fmt.Sprintf("SH%03X", addr&0x7ff), fmt.Sprintf("CRA%03X", (addr+8)&0x7ff)}
+	default:
+		cmds = []string{fmt.Sprintf("SH%06X", addr&0xffffff)}
+	}
+	for _, cmd := range cmds {
+		if strings.HasPrefix(cmd, "CRA") && c.require("CRA") != nil {
+			continue
+		}
+		_, err = c.SendATContext(ctx, cmd)
+		if err != nil {
+			return fmt.Errorf("AT%s: %w", cmd, err)
+		}
+	}
+	c.target = addr
+	return nil
+}
@@ -0,0 +1,138 @@
+package isotp
+
+import (
+	"context"
+	"time"
+)
+
+// Conn is a point to point ISO-TP link that sends on one CAN ID and receives on another
+type Conn struct {
+	bus  Bus
+	tx   uint32
+	rx   uint32
+	cfg  Config
+	recv reassembler
+}
+
+// NewConn will create a Conn that sends with the txID and receives with the rxID
+func NewConn(bus Bus, txID, rxID uint32, cfg Config) *Conn {
+	return &Conn{bus: bus, tx: txID, rx: rxID, cfg: cfg}
+}
+
+// Send will send payload as a single message, segmenting it and honoring flow control as needed
+func (c *Conn) Send(ctx context.Context, payload []byte) error {
+	size := c.cfg.dataLen()
+	if len(payload) < size {
+		return c.bus.Send(ctx, c.cfg.frame(c.tx, append([]byte{frameSingle<<4 | byte(len(payload))}, payload...)))
+	}
+	if len(payload) > maxLength {
+		return ErrTooLong
+	}
+
+	first := append([]byte{frameFirst<<4 | byte(len(payload)>>8), byte(len(payload))}, payload[:size-2]...)
+	err := c.bus.Send(ctx, c.cfg.frame(c.tx, first))
+	if err != nil {
+		return err
+	}
+	payload = payload[size-2:]
+
+	var seq byte = 1
+	for len(payload) > 0 {
+		bs, st, err := c.waitFlow(ctx)
+		if err != nil {
+			return err
+		}
+		for i := 0; (bs == 0 || i < int(bs)) && len(payload) > 0; i++ {
+			if i > 0 && st > 0 {
+				t := time.NewTimer(st)
+				select {
+				case <-t.C:
+				case <-ctx.Done():
+					t.Stop()
+					return ctx.Err()
+				}
+			}
+			n := size - 1
+			if n > len(payload) {
+				n = len(payload)
+			}
+			err = c.bus.Send(ctx, c.cfg.frame(c.tx, append([]byte{frameConsecutive<<4 | seq}, payload[:n]...)))
+			if err != nil {
+				return err
+			}
+			payload = payload[n:]
+			seq = (seq + 1) & 0xf
+		}
+	}
+	return nil
+}
+
+// waitFlow will wait for a flow control frame telling us to continue, and return the block size and separation time
+func (c *Conn) waitFlow(ctx context.Context) (byte, time.Duration, error) {
+	for {
+		data, err := c.receive(ctx, true)
+		if err != nil {
+			return 0, 0, err
+		}
+		if data[0]>>4 != frameFlowControl || len(data) < 3 {
+			continue
+		}
+		switch data[0] & 0xf {
+		case flowContinue:
+			return data[1], decodeSTmin(data[2]), nil
+		case flowOverflow:
+			return 0, 0, ErrOverflow
+		}
+		// flowWait: the receiver will send another flow control frame
+	}
+}
+
+// receive will wait for the next frame from the rx ID. If timeout is set, ErrTimeout is returned if
+// it doesn't arrive within the configured timeout
+func (c *Conn) receive(ctx context.Context, timeout bool) ([]byte, error) {
+	if timeout {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.cfg.timeout())
+		defer cancel()
+	}
+	for {
+		f, err := c.bus.Receive(ctx)
+		if err == context.DeadlineExceeded && timeout {
+			return nil, ErrTimeout
+		}
+		if err != nil {
+			return nil, err
+		}
+		if f.ID != c.rx {
+			continue
+		}
+		data, ok := c.cfg.strip(f)
+		if ok {
+			return data, nil
+		}
+	}
+}
+
+// Receive will wait for the next complete message, sending flow control frames as needed
+func (c *Conn) Receive(ctx context.Context) ([]byte, error) {
+	for {
+		data, err := c.receive(ctx, c.recv.active)
+		if err != nil {
+			c.recv.active = false
+			return nil, err
+		}
+		done, flow, err := c.recv.add(data, c.cfg.BlockSize)
+		if err != nil {
+			return nil, err
+		}
+		if flow {
+			err = c.bus.Send(ctx, c.cfg.frame(c.tx, c.cfg.flowControl()))
+			if err != nil {
+				return nil, err
+			}
+		}
+		if done {
+			return c.recv.data, nil
+		}
+	}
+}
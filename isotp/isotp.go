@@ -0,0 +1,226 @@
+// Package isotp implements ISO 15765-2 (ISO-TP) segmentation on top of raw CAN frames, and
+// an obd2.Transport that uses it.
+package isotp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrTimeout is returned when the other side stops sending frames part way through a message
+	ErrTimeout = errors.New("timed out waiting for frame")
+
+	// ErrOverflow is returned when the receiver reports the message is too large for its buffer
+	ErrOverflow = errors.New("receiver overflow")
+
+	// ErrSequence is returned when a consecutive frame arrives out of sequence
+	ErrSequence = errors.New("consecutive frame out of sequence")
+
+	// ErrTooLong is returned when a payload is too long to send in a single message
+	ErrTooLong = errors.New("payload too long")
+)
+
+// IncompleteError is returned when a multi-frame message stops before all of its frames were received
+type IncompleteError struct {
+	ID       uint32
+	Received int
+	Length   int
+}
+
+func (e *IncompleteError) Error() string {
+	return fmt.Sprintf("incomplete message from %X: received %d of %d bytes", e.ID, e.Received, e.Length)
+}
+
+// Frame is a single classic CAN frame
+type Frame struct {
+	// ID is the CAN identifier
+	ID uint32
+
+	// Extended will be set for 29 bit identifiers
+	Extended bool
+
+	// Data is the frame payload, up to 8 bytes
+	Data []byte
+}
+
+// Bus sends and receives CAN frames. A Bus should deliver every frame it receives; filtering is done by the caller
+type Bus interface {
+	Send(ctx context.Context, f Frame) error
+	Receive(ctx context.Context) (Frame, error)
+}
+
+const (
+	frameSingle      = 0x0
+	frameFirst       = 0x1
+	frameConsecutive = 0x2
+	frameFlowControl = 0x3
+)
+
+const (
+	flowContinue = 0x0
+	flowWait     = 0x1
+	flowOverflow = 0x2
+)
+
+// maxLength is the longest payload that can be sent without the 32 bit length escape
+const maxLength = 0xfff
+
+// Config holds the ISO-TP link parameters
+type Config struct {
+	// Extended selects 29 bit CAN identifiers
+	Extended bool
+
+	// BlockSize is the number of consecutive frames the sender may send before waiting for
+	// another flow control frame. 0 means no limit
+	BlockSize byte
+
+	// STmin is the minimum time the sender should wait between consecutive frames
+	STmin time.Duration
+
+	// Padding will pad every frame to 8 bytes with PadByte
+	Padding bool
+	PadByte byte
+
+	// ExtendedAddressing will prefix the data of every frame with an address byte. TxAddress
+	// is sent, and only frames starting with RxAddress are received
+	ExtendedAddressing bool
+	TxAddress          byte
+	RxAddress          byte
+
+	// Timeout is how long to wait for the next flow control or consecutive frame. Defaults to 1 second
+	Timeout time.Duration
+}
+
+func (cfg *Config) timeout() time.Duration {
+	if cfg.Timeout == 0 {
+		return time.Second
+	}
+	return cfg.Timeout
+}
+
+// dataLen will return the number of bytes available for the PCI and payload in a single frame
+func (cfg *Config) dataLen() int {
+	if cfg.ExtendedAddressing {
+		return 7
+	}
+	return 8
+}
+
+// frame will build a frame for id, adding the address byte and padding
+func (cfg *Config) frame(id uint32, data []byte) Frame {
+	f := Frame{ID: id, Extended: cfg.Extended}
+	if cfg.ExtendedAddressing {
+		f.Data = append(f.Data, cfg.TxAddress)
+	}
+	f.Data = append(f.Data, data...)
+	for cfg.Padding && len(f.Data) < 8 {
+		f.Data = append(f.Data, cfg.PadByte)
+	}
+	return f
+}
+
+// strip will return the frame data without the address byte, or false if the frame isn't addressed to us
+func (cfg *Config) strip(f Frame) ([]byte, bool) {
+	if f.Extended != cfg.Extended || len(f.Data) == 0 {
+		return nil, false
+	}
+	if !cfg.ExtendedAddressing {
+		return f.Data, true
+	}
+	if f.Data[0] != cfg.RxAddress || len(f.Data) < 2 {
+		return nil, false
+	}
+	return f.Data[1:], true
+}
+
+// flowControl will return the flow control frame data telling the sender to continue
+func (cfg *Config) flowControl() []byte {
+	return []byte{frameFlowControl<<4 | flowContinue, cfg.BlockSize, encodeSTmin(cfg.STmin)}
+}
+
+// encodeSTmin will convert d to the STmin byte. Values that can't be represented are rounded up
+func encodeSTmin(d time.Duration) byte {
+	switch {
+	case d <= 0:
+		return 0
+	case d <= 900*time.Microsecond:
+		n := (d + 100*time.Microsecond - 1) / (100 * time.Microsecond)
+		return 0xf0 + byte(n)
+	case d > 127*time.Millisecond:
+		return 127
+	}
+	return byte((d + time.Millisecond - 1) / time.Millisecond)
+}
+
+// decodeSTmin will convert the STmin byte to a duration. Reserved values are treated as the maximum
+func decodeSTmin(v byte) time.Duration {
+	switch {
+	case v <= 0x7f:
+		return time.Duration(v) * time.Millisecond
+	case v >= 0xf1 && v <= 0xf9:
+		return time.Duration(v-0xf0) * 100 * time.Microsecond
+	}
+	return 127 * time.Millisecond
+}
+
+// reassembler collects the frames of a single incoming message
+type reassembler struct {
+	active bool
+	length int
+	data   []byte
+	seq    byte
+	block  int
+}
+
+// add will process the data of a received frame (without the address byte). done is set once a full message
+// is in r.data, and flow is set when a flow control frame should be sent to the sender
+func (r *reassembler) add(data []byte, blockSize byte) (done, flow bool, err error) {
+	switch data[0] >> 4 {
+	case frameSingle:
+		n := int(data[0] & 0xf)
+		if n == 0 || len(data) < n+1 {
+			return false, false, nil
+		}
+		r.active = false
+		r.data = data[1 : n+1]
+		return true, false, nil
+	case frameFirst:
+		if len(data) < 2 {
+			return false, false, nil
+		}
+		r.length = int(data[0]&0xf)<<8 | int(data[1])
+		if r.length == 0 {
+			// 32 bit lengths are not supported
+			return false, false, ErrTooLong
+		}
+		r.active = true
+		r.data = append([]byte(nil), data[2:]...)
+		r.seq = 1
+		r.block = 0
+		return false, true, nil
+	case frameConsecutive:
+		if !r.active {
+			return false, false, nil
+		}
+		if data[0]&0xf != r.seq {
+			r.active = false
+			return false, false, ErrSequence
+		}
+		r.seq = (r.seq + 1) & 0xf
+		r.data = append(r.data, data[1:]...)
+		if len(r.data) >= r.length {
+			r.active = false
+			r.data = r.data[:r.length]
+			return true, false, nil
+		}
+		r.block++
+		if blockSize > 0 && r.block == int(blockSize) {
+			r.block = 0
+			return false, true, nil
+		}
+	}
+	return false, false, nil
+}
@@ -0,0 +1,310 @@
+package isotp
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestEncodeSTmin(t *testing.T) {
+	cases := []struct {
+		d    time.Duration
+		want byte
+	}{
+		{0, 0},
+		{-time.Millisecond, 0},
+		{100 * time.Microsecond, 0xf1},
+		{150 * time.Microsecond, 0xf2},
+		{900 * time.Microsecond, 0xf9},
+		{901 * time.Microsecond, 0x01},
+		{950 * time.Microsecond, 0x01},
+		{time.Millisecond, 0x01},
+		{1500 * time.Microsecond, 0x02},
+		{127 * time.Millisecond, 0x7f},
+		{time.Second, 0x7f},
+	}
+	for _, c := range cases {
+		if got := encodeSTmin(c.d); got != c.want {
+			t.Errorf("encodeSTmin(%s) = 0x%02x; want 0x%02x", c.d, got, c.want)
+		}
+		if c.d > 0 && c.d <= 127*time.Millisecond && decodeSTmin(c.want) < c.d {
+			t.Errorf("decodeSTmin(0x%02x) = %s; want at least %s", c.want, decodeSTmin(c.want), c.d)
+		}
+	}
+	for _, v := range []byte{0x80, 0xf0, 0xfa, 0xff} {
+		if got := decodeSTmin(v); got != 127*time.Millisecond {
+			t.Errorf("decodeSTmin(0x%02x) = %s; want 127ms", v, got)
+		}
+	}
+}
+
+func testPayload(n int) []byte {
+	data := make([]byte, n)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	return data
+}
+
+func testContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+// receiveAsync will start receiving a message on c, the result is sent on the returned channel
+func receiveAsync(ctx context.Context, c *Conn) <-chan []byte {
+	ch := make(chan []byte, 1)
+	go func() {
+		data, err := c.Receive(ctx)
+		if err != nil {
+			data = nil
+		}
+		ch <- data
+	}()
+	return ch
+}
+
+// drain will return the frames already received by n
+func drain(n Bus) []Frame {
+	var frames []Frame
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		f, err := n.Receive(ctx)
+		cancel()
+		if err != nil {
+			return frames
+		}
+		frames = append(frames, f)
+	}
+}
+
+func TestConnSingleFrame(t *testing.T) {
+	ctx := testContext(t)
+	bus := NewMemoryBus()
+	tester := NewConn(bus.Node(), 0x7e0, 0x7e8, Config{Padding: true, PadByte: 0xaa})
+	ecu := NewConn(bus.Node(), 0x7e8, 0x7e0, Config{})
+	raw := bus.Node()
+
+	res := receiveAsync(ctx, ecu)
+	payload := []byte{0x01, 0x0c}
+	err := tester.Send(ctx, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := <-res; !bytes.Equal(got, payload) {
+		t.Errorf("received % x; want % x", got, payload)
+	}
+
+	f, err := raw.Receive(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []byte{0x02, 0x01, 0x0c, 0xaa, 0xaa, 0xaa, 0xaa, 0xaa}
+	if f.ID != 0x7e0 || !bytes.Equal(f.Data, want) {
+		t.Errorf("sent %X % x; want 7E0 % x", f.ID, f.Data, want)
+	}
+}
+
+func TestConnMultiFrame(t *testing.T) {
+	ctx := testContext(t)
+	bus := NewMemoryBus()
+	tester := NewConn(bus.Node(), 0x7e0, 0x7e8, Config{})
+	ecu := NewConn(bus.Node(), 0x7e8, 0x7e0, Config{})
+	sniffer := bus.Node()
+
+	// 1 first frame and 28 consecutive frames, so the sequence number wraps past 0xF
+	payload := testPayload(6 + 7*28)
+	res := receiveAsync(ctx, tester)
+	err := ecu.Send(ctx, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := <-res; !bytes.Equal(got, payload) {
+		t.Fatalf("received % x; want % x", got, payload)
+	}
+
+	var seq byte = 1
+	var cf, fc int
+	for _, f := range drain(sniffer) {
+		switch f.Data[0] >> 4 {
+		case frameFirst:
+			if f.ID != 0x7e8 || f.Data[0] != 0x10 || f.Data[1] != byte(len(payload)) {
+				t.Errorf("first frame %X % x", f.ID, f.Data)
+			}
+		case frameConsecutive:
+			if f.Data[0]&0xf != seq {
+				t.Errorf("consecutive frame %d: sequence %X; want %X", cf, f.Data[0]&0xf, seq)
+			}
+			seq = (seq + 1) & 0xf
+			cf++
+		case frameFlowControl:
+			if f.ID != 0x7e0 || !bytes.Equal(f.Data, []byte{0x30, 0, 0}) {
+				t.Errorf("flow control %X % x", f.ID, f.Data)
+			}
+			fc++
+		}
+	}
+	if cf != 28 || fc != 1 {
+		t.Errorf("got %d consecutive and %d flow control frames; want 28 and 1", cf, fc)
+	}
+}
+
+func TestConnBlockSize(t *testing.T) {
+	ctx := testContext(t)
+	bus := NewMemoryBus()
+	tester := NewConn(bus.Node(), 0x7e0, 0x7e8, Config{BlockSize: 4, STmin: 5 * time.Millisecond})
+	ecu := NewConn(bus.Node(), 0x7e8, 0x7e0, Config{})
+	sniffer := bus.Node()
+
+	payload := testPayload(6 + 7*10)
+	res := receiveAsync(ctx, tester)
+	start := time.Now()
+	err := ecu.Send(ctx, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	elapsed := time.Since(start)
+	if got := <-res; !bytes.Equal(got, payload) {
+		t.Fatalf("received % x; want % x", got, payload)
+	}
+
+	// STmin only applies between the frames of a block: 3 + 3 + 1 gaps for blocks of 4, 4 and 2 frames
+	if elapsed < 7*5*time.Millisecond {
+		t.Errorf("sent in %s; want at least 35ms", elapsed)
+	}
+
+	// flow control after the first frame, then after every 4 consecutive frames
+	var order []byte
+	for _, f := range drain(sniffer) {
+		order = append(order, f.Data[0]>>4)
+		if f.Data[0]>>4 == frameFlowControl && !bytes.Equal(f.Data, []byte{0x30, 4, 5}) {
+			t.Errorf("flow control % x; want 30 04 05", f.Data)
+		}
+	}
+	want := []byte{1, 3, 2, 2, 2, 2, 3, 2, 2, 2, 2, 3, 2, 2}
+	if !bytes.Equal(order, want) {
+		t.Errorf("frame types %v; want %v", order, want)
+	}
+}
+
+func TestConnFlowWait(t *testing.T) {
+	ctx := testContext(t)
+	bus := NewMemoryBus()
+	ecu := NewConn(bus.Node(), 0x7e8, 0x7e0, Config{})
+	raw := bus.Node()
+
+	errc := make(chan error, 1)
+	go func() { errc <- ecu.Send(ctx, testPayload(20)) }()
+
+	f, err := raw.Receive(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Data[0]>>4 != frameFirst {
+		t.Fatalf("got % x; want first frame", f.Data)
+	}
+	for _, fc := range [][]byte{{0x31, 0, 0}, {0x31, 0, 0}, {0x30, 0, 0}} {
+		err = raw.Send(ctx, Frame{ID: 0x7e0, Data: fc})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 1; i <= 2; i++ {
+		f, err = raw.Receive(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.Data[0] != 0x20|byte(i) {
+			t.Errorf("got % x; want consecutive frame %d", f.Data, i)
+		}
+	}
+	if err = <-errc; err != nil {
+		t.Error(err)
+	}
+}
+
+func TestConnFlowOverflow(t *testing.T) {
+	ctx := testContext(t)
+	bus := NewMemoryBus()
+	ecu := NewConn(bus.Node(), 0x7e8, 0x7e0, Config{})
+	raw := bus.Node()
+
+	errc := make(chan error, 1)
+	go func() { errc <- ecu.Send(ctx, testPayload(20)) }()
+
+	_, err := raw.Receive(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = raw.Send(ctx, Frame{ID: 0x7e0, Data: []byte{0x32, 0, 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = <-errc; err != ErrOverflow {
+		t.Errorf("got %v; want ErrOverflow", err)
+	}
+}
+
+func TestConnSequence(t *testing.T) {
+	ctx := testContext(t)
+	bus := NewMemoryBus()
+	tester := NewConn(bus.Node(), 0x7e0, 0x7e8, Config{})
+	raw := bus.Node()
+
+	errc := make(chan error, 1)
+	go func() {
+		_, err := tester.Receive(ctx)
+		errc <- err
+	}()
+	for _, data := range [][]byte{{0x10, 20, 0, 1, 2, 3, 4, 5}, {0x22, 6, 7, 8, 9, 10, 11, 12}} {
+		err := raw.Send(ctx, Frame{ID: 0x7e8, Data: data})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := <-errc; err != ErrSequence {
+		t.Errorf("got %v; want ErrSequence", err)
+	}
+}
+
+func TestConnTimeout(t *testing.T) {
+	ctx := testContext(t)
+	cfg := Config{Timeout: 20 * time.Millisecond}
+
+	t.Run("FlowControl", func(t *testing.T) {
+		ecu := NewConn(NewMemoryBus().Node(), 0x7e8, 0x7e0, cfg)
+		if err := ecu.Send(ctx, testPayload(20)); err != ErrTimeout {
+			t.Errorf("got %v; want ErrTimeout", err)
+		}
+	})
+
+	t.Run("ConsecutiveFrame", func(t *testing.T) {
+		bus := NewMemoryBus()
+		tester := NewConn(bus.Node(), 0x7e0, 0x7e8, cfg)
+		raw := bus.Node()
+		errc := make(chan error, 1)
+		go func() {
+			_, err := tester.Receive(ctx)
+			errc <- err
+		}()
+		err := raw.Send(ctx, Frame{ID: 0x7e8, Data: []byte{0x10, 20, 0, 1, 2, 3, 4, 5}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = <-errc; err != ErrTimeout {
+			t.Errorf("got %v; want ErrTimeout", err)
+		}
+	})
+
+	t.Run("Idle", func(t *testing.T) {
+		// waiting for the start of a message is only bounded by ctx
+		tester := NewConn(NewMemoryBus().Node(), 0x7e0, 0x7e8, cfg)
+		rctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		if _, err := tester.Receive(rctx); err != context.DeadlineExceeded {
+			t.Errorf("got %v; want context.DeadlineExceeded", err)
+		}
+	})
+}
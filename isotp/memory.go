@@ -0,0 +1,57 @@
+package isotp
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryBus is an in-memory CAN bus. Every frame sent by a node is received by every other node
+type MemoryBus struct {
+	mx    sync.Mutex
+	nodes []*memoryNode
+}
+
+type memoryNode struct {
+	bus *MemoryBus
+	ch  chan Frame
+}
+
+// NewMemoryBus will create an empty MemoryBus
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{}
+}
+
+// Node will attach a new node to the bus
+func (b *MemoryBus) Node() Bus {
+	n := &memoryNode{bus: b, ch: make(chan Frame, 256)}
+	b.mx.Lock()
+	b.nodes = append(b.nodes, n)
+	b.mx.Unlock()
+	return n
+}
+
+func (n *memoryNode) Send(ctx context.Context, f Frame) error {
+	f.Data = append([]byte(nil), f.Data...)
+	n.bus.mx.Lock()
+	defer n.bus.mx.Unlock()
+	for _, o := range n.bus.nodes {
+		if o == n {
+			continue
+		}
+		select {
+		case o.ch <- f:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (n *memoryNode) Receive(ctx context.Context) (Frame, error) {
+	select {
+	case f := <-n.ch:
+		return f, nil
+	case <-ctx.Done():
+		return Frame{}, ctx.Err()
+	}
+}
@@ -0,0 +1,165 @@
+package isotp
+
+import (
+	"context"
+	"time"
+
+	"github.com/mastercactapus/obd2"
+)
+
+const (
+	// functional request IDs for OBD
+	functional11 = 0x7df
+	functional29 = 0x18db33f1
+
+	// responsePending is the negative response code an ECU sends when it needs more time
+	responsePending = 0x78
+)
+
+// Transport implements obd2.Transport over ISO-TP, as specified for OBD by ISO 15765-4
+type Transport struct {
+	bus Bus
+	cfg Config
+
+	// Wait is how long to wait for more ECUs to respond after the last frame was received. Defaults to 50ms (P2 CAN)
+	Wait time.Duration
+
+	// PendingWait is how long to wait after an ECU says its response is pending. Defaults to 5 seconds (P2* CAN)
+	PendingWait time.Duration
+}
+
+// NewTransport will create a new Transport on bus. The ExtendedAddressing, TxAddress and RxAddress
+// fields of cfg are ignored, as OBD uses normal addressing
+func NewTransport(bus Bus, cfg Config) *Transport {
+	cfg.ExtendedAddressing = false
+	return &Transport{bus: bus, cfg: cfg}
+}
+
+// RoundTrip will send an OBD request and return the response of every ECU that answered. It satisfies the obd2.Transport interface
+func (t *Transport) RoundTrip(req *obd2.Request) (*obd2.Response, error) {
+	return t.RoundTripContext(context.Background(), req)
+}
+
+// RoundTripContext is like RoundTrip, but will give up waiting when ctx is done. It satisfies the obd2.ContextTransport interface
+func (t *Transport) RoundTripContext(ctx context.Context, req *obd2.Request) (*obd2.Response, error) {
+	tx := uint32(req.Address)
+	if req.Address == obd2.AddressFunctional {
+		tx = functional11
+		if t.cfg.Extended {
+			tx = functional29
+		}
+	}
+	payload := append([]byte{req.Mode}, req.Args...)
+	if req.Address == obd2.AddressFunctional && len(payload) >= t.cfg.dataLen() {
+		// there is no single ECU to send flow control
+		return nil, ErrTooLong
+	}
+	// flow control for a physical request is handled by Send, any frames it skips over are responses to something else
+	err := NewConn(t.bus, tx, t.responseID(tx), t.cfg).Send(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	wait := t.Wait
+	if wait == 0 {
+		wait = 50 * time.Millisecond
+	}
+	pendingWait := t.PendingWait
+	if pendingWait == 0 {
+		pendingWait = 5 * time.Second
+	}
+
+	res := new(obd2.Response)
+	rx := make(map[uint32]*reassembler)
+	deadline := time.Now().Add(wait)
+	var pending time.Time
+	for {
+		rctx, cancel := context.WithDeadline(ctx, deadline)
+		f, err := t.bus.Receive(rctx)
+		cancel()
+		if err == context.DeadlineExceeded && ctx.Err() == nil {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !t.isResponse(tx, f) {
+			continue
+		}
+		data, ok := t.cfg.strip(f)
+		if !ok {
+			continue
+		}
+		r := rx[f.ID]
+		if r == nil {
+			r = new(reassembler)
+			rx[f.ID] = r
+		}
+		done, flow, err := r.add(data, t.cfg.BlockSize)
+		if err != nil {
+			return nil, err
+		}
+		if flow {
+			err = t.bus.Send(ctx, t.cfg.frame(t.requestID(f.ID), t.cfg.flowControl()))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if done {
+			if len(r.data) >= 3 && r.data[0] == 0x7f && r.data[2] == responsePending {
+				pending = time.Now().Add(pendingWait)
+			} else {
+				res.Messages = append(res.Messages, obd2.Message{Source: obd2.Address(f.ID), Data: r.data})
+			}
+		}
+
+		deadline = time.Now().Add(wait)
+		for _, r := range rx {
+			if r.active {
+				deadline = time.Now().Add(t.cfg.timeout())
+				break
+			}
+		}
+		if pending.After(deadline) {
+			deadline = pending
+		}
+	}
+	for id, r := range rx {
+		if r.active {
+			return nil, &IncompleteError{ID: id, Received: len(r.data), Length: r.length}
+		}
+	}
+	return res, nil
+}
+
+// responseID will return the ID an ECU will respond with to a physical request sent to tx
+func (t *Transport) responseID(tx uint32) uint32 {
+	if t.cfg.Extended {
+		// swap target and source
+		return tx&0xffff0000 | (tx&0xff)<<8 | (tx>>8)&0xff
+	}
+	return tx + 8
+}
+
+// requestID will return the physical request ID of the ECU that responds with rx
+func (t *Transport) requestID(rx uint32) uint32 {
+	if t.cfg.Extended {
+		return t.responseID(rx)
+	}
+	return rx - 8
+}
+
+// isResponse will return true if f is from an ECU responding to a request sent to tx
+func (t *Transport) isResponse(tx uint32, f Frame) bool {
+	if f.Extended != t.cfg.Extended {
+		return false
+	}
+	switch {
+	case tx == functional11:
+		return f.ID >= 0x7e8 && f.ID <= 0x7ef
+	case tx == functional29:
+		return f.ID&0xffff00 == 0xdaf100
+	}
+	return f.ID == t.responseID(tx)
+}
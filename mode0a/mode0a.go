@@ -0,0 +1,18 @@
+// Package mode0a reads the permanent trouble codes of each ECU (service 0x0A). Permanent codes can not be cleared
+// by mode 4, the ECU clears them itself once it verifies the fault is gone
+package mode0a
+
+import (
+	"context"
+
+	"github.com/mastercactapus/obd2"
+	"github.com/mastercactapus/obd2/mode3"
+)
+
+// ID is the identifier to use for mode0a commands
+const ID = byte(mode3.Permanent)
+
+// Read will return the permanent trouble codes of every ECU that responded
+func Read(ctx context.Context, c *obd2.Client) (map[obd2.Address][]obd2.DTC, error) {
+	return mode3.Query(ctx, c, ID)
+}
@@ -0,0 +1,259 @@
+package obd2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mastercactapus/obd2/mode1"
+	"github.com/mastercactapus/obd2/units"
+)
+
+// ReadPID will request a mode 1 PID and return the data following the PID echo
+func (c *Client) ReadPID(ctx context.Context, pid byte) ([]byte, error) {
+	return c.readPID(ctx, pid, 0)
+}
+
+// readPID will request a mode 1 PID, ensuring at least n data bytes are returned
+func (c *Client) readPID(ctx context.Context, pid byte, n int) ([]byte, error) {
+	data, err := c.Query(ctx, &Request{Mode: mode1.ID, Args: []byte{pid}})
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 || data[0] != pid {
+		return nil, fmt.Errorf("unexpected response for PID 0x%02x: % x", pid, data)
+	}
+	data = data[1:]
+	if len(data) < n {
+		return nil, fmt.Errorf("short response for PID 0x%02x: expected %d bytes but got %d", pid, n, len(data))
+	}
+	return data, nil
+}
+
+// SupportedPIDs will walk the support PIDs (PIDSupport1, PIDSupport2, ...) and return the mode 1 PIDs supported by each
+// ECU that responded. The walk stops once no ECU reports the next support PID as supported, or none answers it
+func (c *Client) SupportedPIDs(ctx context.Context) (map[Address]mode1.PIDSet, error) {
+	sets := make(map[Address]mode1.PIDSet)
+	for base := 0; base <= int(mode1.PIDSupport8); base += 0x20 {
+		msgs, err := c.QueryAll(ctx, &Request{Mode: mode1.ID, Args: []byte{byte(base)}})
+		var neg NegativeResponse
+		if base > 0 && (errors.Is(err, ErrNoResponse) || errors.As(err, &neg)) {
+			// an ECU that claims more but rejects the next support PID ends the walk
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		more := false
+		for _, m := range msgs {
+			if len(m.Data) < 5 || m.Data[0] != byte(base) {
+				continue
+			}
+			set := sets[m.Source]
+			set.AddSupported(byte(base), m.Data[1:5])
+			sets[m.Source] = set
+			if set.Has(byte(base) + 0x20) {
+				more = true
+			}
+		}
+		if !more {
+			break
+		}
+	}
+	if len(sets) == 0 {
+		return nil, ErrNoResponse
+	}
+	return sets, nil
+}
+
+// readPIDByte will request a mode 1 PID that returns a single byte
+func (c *Client) readPIDByte(ctx context.Context, pid byte) (byte, error) {
+	data, err := c.readPID(ctx, pid, 1)
+	if err != nil {
+		return 0, err
+	}
+	return data[0], nil
+}
+
+// MonitorStatus will return the monitoring status since DTCs were cleared
+func (c *Client) MonitorStatus(ctx context.Context) (mode1.MonitorStatus, error) {
+	data, err := c.readPID(ctx, mode1.PIDMonitorStatus, 4)
+	if err != nil {
+		return mode1.MonitorStatus{}, err
+	}
+	return mode1.DecodeMonitorStatus(data), nil
+}
+
+// FuelSystemStatus will return the loop status of both fuel systems
+func (c *Client) FuelSystemStatus(ctx context.Context) ([2]mode1.FuelSystemStatus, error) {
+	var s [2]mode1.FuelSystemStatus
+	data, err := c.readPID(ctx, mode1.PIDFuelSystemStatus, 2)
+	if err != nil {
+		return s, err
+	}
+	s[0] = mode1.FuelSystemStatus(data[0])
+	s[1] = mode1.FuelSystemStatus(data[1])
+	return s, nil
+}
+
+// EngineLoad will return the calculated engine load
+func (c *Client) EngineLoad(ctx context.Context) (units.Ratio, error) {
+	v, err := c.readPIDByte(ctx, mode1.PIDEngineLoad)
+	if err != nil {
+		return 0, err
+	}
+	return units.Ratio(mode1.DecodeEngineLoad(v)), nil
+}
+
+// ECT will return the engine coolant temperature
+func (c *Client) ECT(ctx context.Context) (units.Temperature, error) {
+	v, err := c.readPIDByte(ctx, mode1.PIDECT)
+	if err != nil {
+		return 0, err
+	}
+	return units.Temperature(mode1.DecodeECT(v)), nil
+}
+
+// FuelTrim will return the fuel trim for one of PIDSTFTBank1, PIDLTFTBank1, PIDSTFTBank2 or PIDLTFTBank2
+func (c *Client) FuelTrim(ctx context.Context, pid byte) (units.Ratio, error) {
+	switch pid {
+	case mode1.PIDSTFTBank1, mode1.PIDLTFTBank1, mode1.PIDSTFTBank2, mode1.PIDLTFTBank2:
+	default:
+		return 0, fmt.Errorf("PID 0x%02x is not a fuel trim PID", pid)
+	}
+	v, err := c.readPIDByte(ctx, pid)
+	if err != nil {
+		return 0, err
+	}
+	return units.Ratio(mode1.DecodeFuelTrim(v)), nil
+}
+
+// FuelPressure will return the fuel pressure (gauge pressure)
+func (c *Client) FuelPressure(ctx context.Context) (units.Pressure, error) {
+	v, err := c.readPIDByte(ctx, mode1.PIDFuelPressure)
+	if err != nil {
+		return 0, err
+	}
+	return units.Pressure(mode1.DecodeFuelPressure(v)), nil
+}
+
+// IntakeMAP will return the intake manifold absolute pressure
+func (c *Client) IntakeMAP(ctx context.Context) (units.Pressure, error) {
+	v, err := c.readPIDByte(ctx, mode1.PIDIntakeMAP)
+	if err != nil {
+		return 0, err
+	}
+	return units.Pressure(v), nil
+}
+
+// EngineRPM will return the engine RPM
+func (c *Client) EngineRPM(ctx context.Context) (float64, error) {
+	data, err := c.readPID(ctx, mode1.PIDEngineRPM, 2)
+	if err != nil {
+		return 0, err
+	}
+	return mode1.DecodeEngineRPM(data), nil
+}
+
+// VehicleSpeed will return the vehicle speed
+func (c *Client) VehicleSpeed(ctx context.Context) (units.Speed, error) {
+	v, err := c.readPIDByte(ctx, mode1.PIDVehicleSpeed)
+	if err != nil {
+		return 0, err
+	}
+	return units.Speed(v), nil
+}
+
+// TimingAdvance will return the timing advance in degrees before TDC
+func (c *Client) TimingAdvance(ctx context.Context) (float64, error) {
+	v, err := c.readPIDByte(ctx, mode1.PIDTimingAdvance)
+	if err != nil {
+		return 0, err
+	}
+	return mode1.DecodeTimingAdvance(v), nil
+}
+
+// IAT will return the intake air temperature
+func (c *Client) IAT(ctx context.Context) (units.Temperature, error) {
+	v, err := c.readPIDByte(ctx, mode1.PIDIAT)
+	if err != nil {
+		return 0, err
+	}
+	return units.Temperature(mode1.DecodeIAT(v)), nil
+}
+
+// MAFRate will return the mass air flow rate
+func (c *Client) MAFRate(ctx context.Context) (units.Flow, error) {
+	data, err := c.readPID(ctx, mode1.PIDMAFRate, 2)
+	if err != nil {
+		return 0, err
+	}
+	return units.Flow(mode1.DecodeMAFRate(data)), nil
+}
+
+// ThrottlePos will return the throttle position
+func (c *Client) ThrottlePos(ctx context.Context) (units.Ratio, error) {
+	v, err := c.readPIDByte(ctx, mode1.PIDThrottlePos)
+	if err != nil {
+		return 0, err
+	}
+	return units.Ratio(mode1.DecodeThrottlePos(v)), nil
+}
+
+// ComAirStatus will return the commanded secondary air status
+func (c *Client) ComAirStatus(ctx context.Context) (mode1.ComAirStatus, error) {
+	v, err := c.readPIDByte(ctx, mode1.PIDComAirStatus)
+	if err != nil {
+		return 0, err
+	}
+	return mode1.ComAirStatus(v), nil
+}
+
+// O2Present will return which oxygen sensors are present
+func (c *Client) O2Present(ctx context.Context) (mode1.O2Present, error) {
+	v, err := c.readPIDByte(ctx, mode1.PIDO2Present)
+	if err != nil {
+		return mode1.O2Present{}, err
+	}
+	return mode1.DecodeO2Present(v), nil
+}
+
+// O2STFT will return the short term fuel trim and voltage for an oxygen sensor (1-8)
+func (c *Client) O2STFT(ctx context.Context, sensor int) (mode1.O2STFT, error) {
+	if sensor < 1 || sensor > 8 {
+		return mode1.O2STFT{}, fmt.Errorf("invalid oxygen sensor %d", sensor)
+	}
+	data, err := c.readPID(ctx, mode1.PIDO2STFT1+byte(sensor-1), 2)
+	if err != nil {
+		return mode1.O2STFT{}, err
+	}
+	return mode1.DecodeO2STFT(data), nil
+}
+
+// OBDStandard will return the OBD standards the vehicle conforms to
+func (c *Client) OBDStandard(ctx context.Context) (mode1.OBDStandard, error) {
+	v, err := c.readPIDByte(ctx, mode1.PIDOBDStandard)
+	if err != nil {
+		return 0, err
+	}
+	return mode1.OBDStandard(v), nil
+}
+
+// AuxInput will return the auxilliary input status
+func (c *Client) AuxInput(ctx context.Context) (mode1.AuxInputStatus, error) {
+	v, err := c.readPIDByte(ctx, mode1.PIDAuxInput)
+	if err != nil {
+		return mode1.AuxInputStatus{}, err
+	}
+	return mode1.DecodeAuxInput(v), nil
+}
+
+// RunTime will return the run time since engine start
+func (c *Client) RunTime(ctx context.Context) (time.Duration, error) {
+	data, err := c.readPID(ctx, mode1.PIDRunTime, 2)
+	if err != nil {
+		return 0, err
+	}
+	return mode1.DecodeRunTime(data), nil
+}
@@ -90,51 +90,51 @@ type TestStatus struct {
 // DecodeMonitorStatus will decode the response of a PIDMonitorStatus request. res must be at least 4 bytes
 func DecodeMonitorStatus(res []byte) MonitorStatus {
 	var s MonitorStatus
-	s.MIL = (1<<7)&res[0] == 1
-	s.DTCCount = int((1 << 7) ^ res[0])
+	s.MIL = (1<<7)&res[0] != 0
+	s.DTCCount = int(res[0] &^ (1 << 7))
 
 	// FYI B7 should be 0 -- reserved, but we don't care
-	s.Misfire.Available = (res[1] & 1) == 1
+	s.Misfire.Available = (res[1] & 1) != 0
 	s.Misfire.Complete = (res[1] & (1 << 4)) == 0
-	s.FuelSystem.Available = (res[1] & (1 << 1)) == 1
+	s.FuelSystem.Available = (res[1] & (1 << 1)) != 0
 	s.FuelSystem.Complete = (res[1] & (1 << 5)) == 0
-	s.Components.Available = (res[1] & (1 << 2)) == 1
+	s.Components.Available = (res[1] & (1 << 2)) != 0
 	s.Components.Complete = (res[1] & (1 << 6)) == 0
 
-	comp := res[1]&(1<<3) == 1
+	comp := res[1]&(1<<3) != 0
 
 	if comp {
 		c := new(MonitorStatusCompression)
-		c.NMHCCatalyst.Available = (res[2] & 1) == 1
+		c.NMHCCatalyst.Available = (res[2] & 1) != 0
 		c.NMHCCatalyst.Complete = (res[3] & 1) == 0
-		c.NOxSCRMonitor.Available = (res[2] & (1 << 1)) == 1
+		c.NOxSCRMonitor.Available = (res[2] & (1 << 1)) != 0
 		c.NOxSCRMonitor.Complete = (res[3] & (1 << 1)) == 0
-		c.BoostPressure.Available = (res[2] & (1 << 3)) == 1
+		c.BoostPressure.Available = (res[2] & (1 << 3)) != 0
 		c.BoostPressure.Complete = (res[3] & (1 << 3)) == 0
-		c.ExhauseGasSensor.Available = (res[2] & (1 << 5)) == 1
+		c.ExhauseGasSensor.Available = (res[2] & (1 << 5)) != 0
 		c.ExhauseGasSensor.Complete = (res[3] & (1 << 5)) == 0
-		c.PMFilter.Available = (res[2] & (1 << 6)) == 1
+		c.PMFilter.Available = (res[2] & (1 << 6)) != 0
 		c.PMFilter.Complete = (res[3] & (1 << 6)) == 0
-		c.EGRVTT.Available = (res[2] & (1 << 7)) == 1
+		c.EGRVTT.Available = (res[2] & (1 << 7)) != 0
 		c.EGRVTT.Complete = (res[3] & (1 << 7)) == 0
 		s.Compression = c
 	} else {
 		sp := new(MonitorStatusSpark)
-		sp.Catalyst.Available = (res[2] & 1) == 1
+		sp.Catalyst.Available = (res[2] & 1) != 0
 		sp.Catalyst.Complete = (res[3] & 1) == 0
-		sp.HeatedCatalyst.Available = (res[2] & (1 << 1)) == 1
+		sp.HeatedCatalyst.Available = (res[2] & (1 << 1)) != 0
 		sp.HeatedCatalyst.Complete = (res[3] & (1 << 1)) == 0
-		sp.EvapSystem.Available = (res[2] & (1 << 2)) == 1
+		sp.EvapSystem.Available = (res[2] & (1 << 2)) != 0
 		sp.EvapSystem.Complete = (res[3] & (1 << 2)) == 0
-		sp.SecondaryAir.Available = (res[2] & (1 << 3)) == 1
+		sp.SecondaryAir.Available = (res[2] & (1 << 3)) != 0
 		sp.SecondaryAir.Complete = (res[3] & (1 << 3)) == 0
-		sp.ACRefrigerant.Available = (res[2] & (1 << 4)) == 1
+		sp.ACRefrigerant.Available = (res[2] & (1 << 4)) != 0
 		sp.ACRefrigerant.Complete = (res[3] & (1 << 4)) == 0
-		sp.O2Sensor.Available = (res[2] & (1 << 5)) == 1
+		sp.O2Sensor.Available = (res[2] & (1 << 5)) != 0
 		sp.O2Sensor.Complete = (res[3] & (1 << 5)) == 0
-		sp.O2SensorHeater.Available = (res[2] & (1 << 6)) == 1
+		sp.O2SensorHeater.Available = (res[2] & (1 << 6)) != 0
 		sp.O2SensorHeater.Complete = (res[3] & (1 << 6)) == 0
-		sp.EGRSystem.Available = (res[2] & (1 << 7)) == 1
+		sp.EGRSystem.Available = (res[2] & (1 << 7)) != 0
 		sp.EGRSystem.Complete = (res[3] & (1 << 7)) == 0
 		s.Spark = sp
 	}
@@ -146,17 +146,18 @@ func DecodeFuelPressure(v byte) int {
 	return int(v) * 3
 }
 
-// IsSupported will determine if a particular PID is supported given the response slice. The slice must be long enough to contain
-// the PID or it will panic. (1 bit per PID, 8 bits per byte; so to check PID 10/0x0a, res must have a length of at least 2)
+// IsSupported will determine if a particular PID is supported given the response slice. pid is relative to the support PID
+// that was requested, from 1 to 32. The slice must be long enough to contain the PID or it will panic.
+// (1 bit per PID, most significant bit first; so to check PID 10/0x0a, res must have a length of at least 2)
 func IsSupported(res []byte, pid byte) bool {
-	byteIndex := pid / 8
-	bitIndex := pid % 8
-	return (res[byteIndex] & (1 << bitIndex)) == 1
+	byteIndex := (pid - 1) / 8
+	bitIndex := 7 - (pid-1)%8
+	return res[byteIndex]&(1<<bitIndex) != 0
 }
 
 // DecodeFuelTrim will return the fuel trim value as a percentage of rich or lean (-1 to 1, respectively)
 func DecodeFuelTrim(v byte) float64 {
-	return float64(v)/128 - 100
+	return float64(v)/128 - 1
 }
 
 // DecodeEngineLoad will decode the engine load as a percentage (between 0 and 1)
@@ -203,14 +204,14 @@ type O2Present struct {
 // DecodeO2Present will decode what oxygen sensors are present. Must have 2 or 4 bytes
 func DecodeO2Present(v byte) O2Present {
 	var p O2Present
-	p.Bank1[0] = v&1 == 1
-	p.Bank1[1] = v&(1<<1) == 1
-	p.Bank1[2] = v&(1<<2) == 1
-	p.Bank1[3] = v&(1<<3) == 1
-	p.Bank2[0] = v&(1<<4) == 1
-	p.Bank2[1] = v&(1<<5) == 1
-	p.Bank2[2] = v&(1<<6) == 1
-	p.Bank2[3] = v&(1<<7) == 1
+	p.Bank1[0] = v&1 != 0
+	p.Bank1[1] = v&(1<<1) != 0
+	p.Bank1[2] = v&(1<<2) != 0
+	p.Bank1[3] = v&(1<<3) != 0
+	p.Bank2[0] = v&(1<<4) != 0
+	p.Bank2[1] = v&(1<<5) != 0
+	p.Bank2[2] = v&(1<<6) != 0
+	p.Bank2[3] = v&(1<<7) != 0
 	return p
 }
 
@@ -230,7 +231,7 @@ type O2STFT struct {
 func DecodeO2STFT(res []byte) O2STFT {
 	var o O2STFT
 	o.Voltage = float64(res[0]) / 200
-	o.STFT = 100*float64(res[1])/128 - 100
+	o.STFT = float64(res[1])/128 - 1
 	o.SensorUsed = res[1] != 0xff
 	return o
 }
@@ -244,11 +245,200 @@ type AuxInputStatus struct {
 // DecodeAuxInput will decode the response to PIDAuxInput
 func DecodeAuxInput(v byte) AuxInputStatus {
 	var s AuxInputStatus
-	s.PTO = v&1 == 1
+	s.PTO = v&1 != 0
 	return s
 }
 
 // DecodeRunTime will decode engine runtime from a response. res must be 2 bytes
 func DecodeRunTime(res []byte) time.Duration {
-	return time.Duration(binary.BigEndian.Uint16(res))
+	return time.Duration(binary.BigEndian.Uint16(res)) * time.Second
+}
+
+// DecodeDistance will decode a distance in km (for PIDDistanceMIL and PIDDistanceSinceClear). res must be 2 bytes
+func DecodeDistance(res []byte) int {
+	return int(binary.BigEndian.Uint16(res))
+}
+
+// DecodeFuelRailPressureRelative will return the fuel rail pressure, relative to manifold vacuum, in kPa. res must be 2 bytes
+func DecodeFuelRailPressureRelative(res []byte) float64 {
+	return float64(binary.BigEndian.Uint16(res)) * 0.079
+}
+
+// DecodeFuelRailPressure will return the fuel rail gauge pressure in kPa. res must be 2 bytes
+func DecodeFuelRailPressure(res []byte) int {
+	return int(binary.BigEndian.Uint16(res)) * 10
+}
+
+// O2WRVoltage is the reading of a wide-band oxygen sensor that reports voltage
+type O2WRVoltage struct {
+	// Lambda is the fuel-air equivalence ratio (1 is stoichiometric, less than 1 is rich)
+	Lambda float64
+
+	// Voltage is the voltage measured
+	Voltage float64
+}
+
+// DecodeO2WRVoltage will decode the response for a PIDO2WRVoltagex request. res must be 4 bytes
+func DecodeO2WRVoltage(res []byte) O2WRVoltage {
+	var o O2WRVoltage
+	o.Lambda = float64(binary.BigEndian.Uint16(res)) * 2 / 65536
+	o.Voltage = float64(binary.BigEndian.Uint16(res[2:])) * 8 / 65536
+	return o
+}
+
+// DecodeCommandedEGR will decode the commanded EGR as a percentage (between 0 and 1)
+func DecodeCommandedEGR(v byte) float64 {
+	return float64(v) / 255
+}
+
+// DecodeEGRError will decode the EGR error as a percentage of the commanded EGR (-1 to 1)
+func DecodeEGRError(v byte) float64 {
+	return float64(v)/128 - 1
+}
+
+// DecodeEvapPurge will decode the commanded evaporative purge as a percentage (between 0 and 1)
+func DecodeEvapPurge(v byte) float64 {
+	return float64(v) / 255
+}
+
+// DecodeFuelTankLevel will decode the fuel tank level as a percentage (between 0 and 1)
+func DecodeFuelTankLevel(v byte) float64 {
+	return float64(v) / 255
+}
+
+// DecodeWarmUps will decode the number of warm-ups since codes were cleared
+func DecodeWarmUps(v byte) int {
+	return int(v)
+}
+
+// DecodeEvapVaporPressure will return the evaporative system vapor pressure in Pa. res must be 2 bytes
+func DecodeEvapVaporPressure(res []byte) float64 {
+	return float64(int16(binary.BigEndian.Uint16(res))) / 4
+}
+
+// DecodeBarometricPressure will return the absolute barometric pressure in kPa
+func DecodeBarometricPressure(v byte) int {
+	return int(v)
+}
+
+// O2WRCurrent is the reading of a wide-band oxygen sensor that reports current
+type O2WRCurrent struct {
+	// Lambda is the fuel-air equivalence ratio (1 is stoichiometric, less than 1 is rich)
+	Lambda float64
+
+	// Current is the current measured in mA
+	Current float64
+}
+
+// DecodeO2WRCurrent will decode the response for a PIDO2WRCurrentx request. res must be 4 bytes
+func DecodeO2WRCurrent(res []byte) O2WRCurrent {
+	var o O2WRCurrent
+	o.Lambda = float64(binary.BigEndian.Uint16(res)) * 2 / 65536
+	o.Current = float64(binary.BigEndian.Uint16(res[2:]))/256 - 128
+	return o
+}
+
+// DecodeCatalystTemp will return a catalyst temperature in degrees Celsius. res must be 2 bytes
+func DecodeCatalystTemp(res []byte) float64 {
+	return float64(binary.BigEndian.Uint16(res))/10 - 40
+}
+
+// DecodeControlModuleVoltage will return the control module voltage. res must be 2 bytes
+func DecodeControlModuleVoltage(res []byte) float64 {
+	return float64(binary.BigEndian.Uint16(res)) / 1000
+}
+
+// DecodeAbsoluteLoad will decode the absolute load value as a percentage (between 0 and about 257). res must be 2 bytes
+func DecodeAbsoluteLoad(res []byte) float64 {
+	return float64(binary.BigEndian.Uint16(res)) / 255
+}
+
+// DecodeCommandedEquivRatio will decode the commanded fuel-air equivalence ratio (1 is stoichiometric). res must be 2 bytes
+func DecodeCommandedEquivRatio(res []byte) float64 {
+	return float64(binary.BigEndian.Uint16(res)) * 2 / 65536
+}
+
+// DecodeAmbientAirTemp will convert the response for PIDAmbientAirTemp to degrees Celsius
+func DecodeAmbientAirTemp(v byte) int {
+	return int(v) - 40
+}
+
+// DecodePedalPos will decode an accelerator pedal position as a percentage (between 0 and 1)
+func DecodePedalPos(v byte) float64 {
+	return float64(v) / 255
+}
+
+// DecodeCommandedThrottleActuator will decode the commanded throttle actuator as a percentage (between 0 and 1)
+func DecodeCommandedThrottleActuator(v byte) float64 {
+	return float64(v) / 255
+}
+
+// DecodeMinutes will decode a time in minutes (for PIDTimeMIL and PIDTimeSinceClear). res must be 2 bytes
+func DecodeMinutes(res []byte) time.Duration {
+	return time.Duration(binary.BigEndian.Uint16(res)) * time.Minute
+}
+
+// MaxValues is the decoded result of PIDMaxValues
+type MaxValues struct {
+	// EquivRatio is the maximum fuel-air equivalence ratio
+	EquivRatio int
+
+	// O2Voltage is the maximum oxygen sensor voltage
+	O2Voltage int
+
+	// O2Current is the maximum oxygen sensor current in mA
+	O2Current int
+
+	// IntakeMAP is the maximum intake manifold absolute pressure in kPa
+	IntakeMAP int
+}
+
+// DecodeMaxValues will decode the response for PIDMaxValues. res must be 4 bytes
+func DecodeMaxValues(res []byte) MaxValues {
+	return MaxValues{
+		EquivRatio: int(res[0]),
+		O2Voltage:  int(res[1]),
+		O2Current:  int(res[2]),
+		IntakeMAP:  int(res[3]) * 10,
+	}
+}
+
+// DecodeMaxMAFRate will return the maximum MAF rate in grams/sec. res must be at least 1 byte (the other 3 are reserved)
+func DecodeMaxMAFRate(res []byte) int {
+	return int(res[0]) * 10
+}
+
+// DecodeEthanolPercent will decode the ethanol fuel percentage (between 0 and 1)
+func DecodeEthanolPercent(v byte) float64 {
+	return float64(v) / 255
+}
+
+// DecodeEvapPressureAbsolute will return the absolute evaporative system vapor pressure in kPa. res must be 2 bytes
+func DecodeEvapPressureAbsolute(res []byte) float64 {
+	return float64(binary.BigEndian.Uint16(res)) / 200
+}
+
+// DecodeEvapVaporPressureWide will return the evaporative system vapor pressure in Pa. res must be 2 bytes
+func DecodeEvapVaporPressureWide(res []byte) int {
+	return int(int16(binary.BigEndian.Uint16(res)))
+}
+
+// DecodeHybridBatteryLife will decode the hybrid battery pack remaining life as a percentage (between 0 and 1)
+func DecodeHybridBatteryLife(v byte) float64 {
+	return float64(v) / 255
+}
+
+// DecodeEngineOilTemp will convert the response for PIDEngineOilTemp to degrees Celsius
+func DecodeEngineOilTemp(v byte) int {
+	return int(v) - 40
+}
+
+// DecodeFuelInjectionTiming will return the fuel injection timing in degrees (negative is before TDC). res must be 2 bytes
+func DecodeFuelInjectionTiming(res []byte) float64 {
+	return float64(binary.BigEndian.Uint16(res))/128 - 210
+}
+
+// DecodeEngineFuelRate will return the engine fuel rate in L/h. res must be 2 bytes
+func DecodeEngineFuelRate(res []byte) float64 {
+	return float64(binary.BigEndian.Uint16(res)) / 20
 }
@@ -0,0 +1,582 @@
+package mode1
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+)
+
+// scale will round v to the nearest integer, clamped between 0 and max
+func scale(v, max float64) uint32 {
+	v = math.Round(v)
+	switch {
+	case v < 0 || math.IsNaN(v):
+		return 0
+	case v > max:
+		return uint32(max)
+	}
+	return uint32(v)
+}
+
+func scaleByte(v float64) byte     { return byte(scale(v, math.MaxUint8)) }
+func scaleUint16(v float64) uint16 { return uint16(scale(v, math.MaxUint16)) }
+
+func putUint16(v uint16) []byte {
+	res := make([]byte, 2)
+	binary.BigEndian.PutUint16(res, v)
+	return res
+}
+
+func putUint32(v uint32) []byte {
+	res := make([]byte, 4)
+	binary.BigEndian.PutUint32(res, v)
+	return res
+}
+
+// encodeSensors will encode vals as the support bit mask followed by size bytes per value. It is the inverse of decodeSensors
+func encodeSensors(vals []SensorValue, size int, conv func(v float64) uint32) []byte {
+	res := make([]byte, 1+len(vals)*size)
+	for i, v := range vals {
+		if v.Supported {
+			res[0] |= 1 << uint(i)
+		}
+		raw := conv(v.Value)
+		for j := size; j > 0; j-- {
+			res[i*size+j] = byte(raw)
+			raw >>= 8
+		}
+	}
+	return res
+}
+
+func testBits(t TestStatus, bit uint) (avail, incomplete byte) {
+	if t.Available {
+		avail = 1 << bit
+	}
+	if !t.Complete {
+		incomplete = 1 << bit
+	}
+	return avail, incomplete
+}
+
+// EncodeMonitorStatus will encode s as the response to PIDMonitorStatus. If s.Compression is set, the compression-ignition
+// tests are encoded, otherwise the spark-ignition tests (if any)
+func EncodeMonitorStatus(s MonitorStatus) []byte {
+	res := make([]byte, 4)
+	res[0] = byte(s.DTCCount) & 0x7f
+	if s.MIL {
+		res[0] |= 1 << 7
+	}
+	set := func(i int, t TestStatus, bit uint) {
+		a, c := testBits(t, bit)
+		res[i] |= a
+		res[i+1] |= c
+	}
+	for i, t := range []TestStatus{s.Misfire, s.FuelSystem, s.Components} {
+		a, c := testBits(t, uint(i))
+		res[1] |= a | c<<4
+	}
+	switch {
+	case s.Compression != nil:
+		res[1] |= 1 << 3
+		c := s.Compression
+		set(2, c.NMHCCatalyst, 0)
+		set(2, c.NOxSCRMonitor, 1)
+		set(2, c.BoostPressure, 3)
+		set(2, c.ExhauseGasSensor, 5)
+		set(2, c.PMFilter, 6)
+		set(2, c.EGRVTT, 7)
+	case s.Spark != nil:
+		sp := s.Spark
+		for i, t := range []TestStatus{sp.Catalyst, sp.HeatedCatalyst, sp.EvapSystem, sp.SecondaryAir, sp.ACRefrigerant, sp.O2Sensor, sp.O2SensorHeater, sp.EGRSystem} {
+			set(2, t, uint(i))
+		}
+	}
+	return res
+}
+
+// EncodeFuelPressure will encode a fuel pressure in kPa
+func EncodeFuelPressure(kPa int) byte {
+	return scaleByte(float64(kPa) / 3)
+}
+
+// EncodeFuelTrim will encode a fuel trim as a percentage of rich or lean (-1 to 1, respectively)
+func EncodeFuelTrim(trim float64) byte {
+	return scaleByte((trim + 1) * 128)
+}
+
+// EncodeEngineLoad will encode the engine load as a percentage (between 0 and 1)
+func EncodeEngineLoad(load float64) byte {
+	return scaleByte(load * 255)
+}
+
+// EncodeECT will encode an engine coolant temperature in degrees Celsius
+func EncodeECT(c int) byte {
+	return scaleByte(float64(c + 40))
+}
+
+// EncodeEngineRPM will encode an engine speed in RPM
+func EncodeEngineRPM(rpm float64) []byte {
+	return putUint16(scaleUint16(rpm * 4))
+}
+
+// EncodeTimingAdvance will encode a timing advance in degrees before TDC
+func EncodeTimingAdvance(deg float64) byte {
+	return scaleByte((deg + 64) * 2)
+}
+
+// EncodeIAT will encode an intake air temperature in degrees Celsius
+func EncodeIAT(c int) byte {
+	return scaleByte(float64(c + 40))
+}
+
+// EncodeMAFRate will encode a mass air flow rate in grams/sec
+func EncodeMAFRate(rate float64) []byte {
+	return putUint16(scaleUint16(rate * 100))
+}
+
+// EncodeThrottlePos will encode a throttle position as a percentage (between 0 and 1)
+func EncodeThrottlePos(pos float64) byte {
+	return scaleByte(pos * 255)
+}
+
+// EncodeO2Present will encode which oxygen sensors are present
+func EncodeO2Present(p O2Present) byte {
+	var v byte
+	for i := range p.Bank1 {
+		if p.Bank1[i] {
+			v |= 1 << uint(i)
+		}
+		if p.Bank2[i] {
+			v |= 1 << uint(i+4)
+		}
+	}
+	return v
+}
+
+// EncodeO2STFT will encode the response for a PIDO2STFTx request. If o.SensorUsed is false, STFT is ignored
+func EncodeO2STFT(o O2STFT) []byte {
+	trim := byte(0xff)
+	if o.SensorUsed {
+		trim = byte(scale((o.STFT+1)*128, 0xfe))
+	}
+	return []byte{scaleByte(o.Voltage * 200), trim}
+}
+
+// EncodeAuxInput will encode the response to PIDAuxInput
+func EncodeAuxInput(s AuxInputStatus) byte {
+	if s.PTO {
+		return 1
+	}
+	return 0
+}
+
+// EncodeRunTime will encode the engine run time, with a resolution of 1 second
+func EncodeRunTime(d time.Duration) []byte {
+	return putUint16(scaleUint16(d.Seconds()))
+}
+
+// EncodeDistance will encode a distance in km (for PIDDistanceMIL and PIDDistanceSinceClear)
+func EncodeDistance(km int) []byte {
+	return putUint16(scaleUint16(float64(km)))
+}
+
+// EncodeFuelRailPressureRelative will encode the fuel rail pressure, relative to manifold vacuum, in kPa
+func EncodeFuelRailPressureRelative(kPa float64) []byte {
+	return putUint16(scaleUint16(kPa / 0.079))
+}
+
+// EncodeFuelRailPressure will encode a fuel rail gauge pressure in kPa
+func EncodeFuelRailPressure(kPa int) []byte {
+	return putUint16(scaleUint16(float64(kPa) / 10))
+}
+
+// EncodeO2WRVoltage will encode the response for a PIDO2WRVoltagex request
+func EncodeO2WRVoltage(o O2WRVoltage) []byte {
+	return append(putUint16(scaleUint16(o.Lambda*65536/2)), putUint16(scaleUint16(o.Voltage*65536/8))...)
+}
+
+// EncodeCommandedEGR will encode the commanded EGR as a percentage (between 0 and 1)
+func EncodeCommandedEGR(egr float64) byte {
+	return scaleByte(egr * 255)
+}
+
+// EncodeEGRError will encode the EGR error as a percentage of the commanded EGR (-1 to 1)
+func EncodeEGRError(e float64) byte {
+	return scaleByte((e + 1) * 128)
+}
+
+// EncodeEvapPurge will encode the commanded evaporative purge as a percentage (between 0 and 1)
+func EncodeEvapPurge(purge float64) byte {
+	return scaleByte(purge * 255)
+}
+
+// EncodeFuelTankLevel will encode the fuel tank level as a percentage (between 0 and 1)
+func EncodeFuelTankLevel(level float64) byte {
+	return scaleByte(level * 255)
+}
+
+// EncodeWarmUps will encode the number of warm-ups since codes were cleared
+func EncodeWarmUps(n int) byte {
+	return scaleByte(float64(n))
+}
+
+// EncodeEvapVaporPressure will encode the evaporative system vapor pressure in Pa
+func EncodeEvapVaporPressure(pa float64) []byte {
+	return putUint16(uint16(scale(pa*4+32768, math.MaxUint16) - 32768))
+}
+
+// EncodeBarometricPressure will encode the absolute barometric pressure in kPa
+func EncodeBarometricPressure(kPa int) byte {
+	return scaleByte(float64(kPa))
+}
+
+// EncodeO2WRCurrent will encode the response for a PIDO2WRCurrentx request
+func EncodeO2WRCurrent(o O2WRCurrent) []byte {
+	return append(putUint16(scaleUint16(o.Lambda*65536/2)), putUint16(scaleUint16((o.Current+128)*256))...)
+}
+
+// EncodeCatalystTemp will encode a catalyst temperature in degrees Celsius
+func EncodeCatalystTemp(c float64) []byte {
+	return putUint16(scaleUint16((c + 40) * 10))
+}
+
+// EncodeControlModuleVoltage will encode the control module voltage
+func EncodeControlModuleVoltage(v float64) []byte {
+	return putUint16(scaleUint16(v * 1000))
+}
+
+// EncodeAbsoluteLoad will encode the absolute load value as a percentage (between 0 and about 257)
+func EncodeAbsoluteLoad(load float64) []byte {
+	return putUint16(scaleUint16(load * 255))
+}
+
+// EncodeCommandedEquivRatio will encode the commanded fuel-air equivalence ratio
+func EncodeCommandedEquivRatio(ratio float64) []byte {
+	return putUint16(scaleUint16(ratio * 65536 / 2))
+}
+
+// EncodeAmbientAirTemp will encode the ambient air temperature in degrees Celsius
+func EncodeAmbientAirTemp(c int) byte {
+	return scaleByte(float64(c + 40))
+}
+
+// EncodePedalPos will encode an accelerator pedal position as a percentage (between 0 and 1)
+func EncodePedalPos(pos float64) byte {
+	return scaleByte(pos * 255)
+}
+
+// EncodeCommandedThrottleActuator will encode the commanded throttle actuator as a percentage (between 0 and 1)
+func EncodeCommandedThrottleActuator(pos float64) byte {
+	return scaleByte(pos * 255)
+}
+
+// EncodeMinutes will encode a time with a resolution of 1 minute (for PIDTimeMIL and PIDTimeSinceClear)
+func EncodeMinutes(d time.Duration) []byte {
+	return putUint16(scaleUint16(d.Minutes()))
+}
+
+// EncodeMaxValues will encode the response for PIDMaxValues
+func EncodeMaxValues(m MaxValues) []byte {
+	return []byte{
+		scaleByte(float64(m.EquivRatio)),
+		scaleByte(float64(m.O2Voltage)),
+		scaleByte(float64(m.O2Current)),
+		scaleByte(float64(m.IntakeMAP) / 10),
+	}
+}
+
+// EncodeMaxMAFRate will encode the maximum MAF rate in grams/sec
+func EncodeMaxMAFRate(rate int) []byte {
+	return []byte{scaleByte(float64(rate) / 10), 0, 0, 0}
+}
+
+// EncodeEthanolPercent will encode the ethanol fuel percentage (between 0 and 1)
+func EncodeEthanolPercent(p float64) byte {
+	return scaleByte(p * 255)
+}
+
+// EncodeEvapPressureAbsolute will encode the absolute evaporative system vapor pressure in kPa
+func EncodeEvapPressureAbsolute(kPa float64) []byte {
+	return putUint16(scaleUint16(kPa * 200))
+}
+
+// EncodeEvapVaporPressureWide will encode the evaporative system vapor pressure in Pa
+func EncodeEvapVaporPressureWide(pa int) []byte {
+	return putUint16(uint16(scale(float64(pa)+32768, math.MaxUint16) - 32768))
+}
+
+// EncodeHybridBatteryLife will encode the hybrid battery pack remaining life as a percentage (between 0 and 1)
+func EncodeHybridBatteryLife(life float64) byte {
+	return scaleByte(life * 255)
+}
+
+// EncodeEngineOilTemp will encode the engine oil temperature in degrees Celsius
+func EncodeEngineOilTemp(c int) byte {
+	return scaleByte(float64(c + 40))
+}
+
+// EncodeFuelInjectionTiming will encode the fuel injection timing in degrees (negative is before TDC)
+func EncodeFuelInjectionTiming(deg float64) []byte {
+	return putUint16(scaleUint16((deg + 210) * 128))
+}
+
+// EncodeEngineFuelRate will encode the engine fuel rate in L/h
+func EncodeEngineFuelRate(rate float64) []byte {
+	return putUint16(scaleUint16(rate * 20))
+}
+
+// EncodeTorquePercent will encode a percent torque value (between -1.25 and 1.30)
+func EncodeTorquePercent(t float64) byte {
+	return scaleByte((t + 1.25) * 100)
+}
+
+// EncodeReferenceTorque will encode the engine reference torque in Nm
+func EncodeReferenceTorque(nm int) []byte {
+	return putUint16(scaleUint16(float64(nm)))
+}
+
+// EncodeTorqueData will encode the response for PIDTorqueData
+func EncodeTorqueData(t TorqueData) []byte {
+	res := []byte{EncodeTorquePercent(t.Idle)}
+	for _, p := range t.Points {
+		res = append(res, EncodeTorquePercent(p))
+	}
+	return res
+}
+
+// EncodeAuxIO will encode the response for PIDAuxIO
+func EncodeAuxIO(a AuxIO) []byte {
+	res := make([]byte, 2)
+	for i, s := range []SensorState{a.PTO, a.AutoTransNeutral, a.ManualTransNeutral, a.GlowPlugLamp} {
+		if s.Supported {
+			res[0] |= 1 << uint(i)
+		}
+		if s.Active {
+			res[1] |= 1 << uint(i)
+		}
+	}
+	return res
+}
+
+// EncodeMAFSensors will encode the mass air flow of sensors A and B in grams/sec
+func EncodeMAFSensors(p SensorPair) []byte {
+	return encodeSensors([]SensorValue{p.A, p.B}, 2, func(v float64) uint32 { return scale(v*32, math.MaxUint16) })
+}
+
+// EncodeECTSensors will encode the engine coolant temperature of sensors 1 (A) and 2 (B) in degrees Celsius
+func EncodeECTSensors(p SensorPair) []byte {
+	return encodeSensors([]SensorValue{p.A, p.B}, 1, func(v float64) uint32 { return scale(v+40, math.MaxUint8) })
+}
+
+// EncodeBankTemps will encode temperatures in degrees Celsius of n sensors on bank 1 and 2 (3 for PIDIATSensors, 2 for
+// PIDEGRTemp and PIDChargeAirCoolerTemp). n is limited to 1 to 3, the layouts DecodeBankTemps accepts
+func EncodeBankTemps(s BankSensors, n int) []byte {
+	if n < 1 {
+		n = 1
+	}
+	if n > 3 {
+		n = 3
+	}
+	vals := append(append([]SensorValue{}, s.Bank1[:n]...), s.Bank2[:n]...)
+	return encodeSensors(vals, 1, func(v float64) uint32 { return scale(v+40, math.MaxUint8) })
+}
+
+// EncodeEGRControl will encode the commanded and actual duty cycle, and error, of EGR A and B. See DecodeEGRControl
+func EncodeEGRControl(c EGRControl) []byte {
+	vals := []SensorValue{c.A.Commanded, c.A.Actual, c.A.Error, c.B.Commanded, c.B.Actual, c.B.Error}
+	res := encodeSensors(vals, 1, func(v float64) uint32 { return scale(v*255, math.MaxUint8) })
+	for _, i := range []int{2, 5} {
+		res[i+1] = EncodeEGRError(vals[i].Value)
+	}
+	return res
+}
+
+// encodeControlPair will encode commanded and actual values for A and B, of size bytes each
+func encodeControlPair(p ControlPair, size int, conv func(v float64) uint32) []byte {
+	return encodeSensors([]SensorValue{p.A.Commanded, p.A.Actual, p.B.Commanded, p.B.Actual}, size, conv)
+}
+
+// controlStatus will return the status byte that ends PIDBoostPressureControl and PIDVGTControl. It has 2 bits each for
+// A and B, set to closed loop for each that is supported
+func controlStatus(p ControlPair) byte {
+	var status byte
+	if p.A.Commanded.Supported || p.A.Actual.Supported {
+		status |= 2
+	}
+	if p.B.Commanded.Supported || p.B.Actual.Supported {
+		status |= 2 << 2
+	}
+	return status
+}
+
+// EncodePercentSensors will encode commanded and actual/relative positions as a percentage (between 0 and 1) for A and B
+// (for PIDIntakeAirFlowControl, PIDThrottleActuatorControl and PIDWastegateControl). Use EncodeVGTControl for PIDVGTControl
+func EncodePercentSensors(p ControlPair) []byte {
+	return encodeControlPair(p, 1, func(v float64) uint32 { return scale(v*255, math.MaxUint8) })
+}
+
+// EncodeVGTControl will encode the commanded and actual variable geometry turbo position as a percentage (between 0 and 1)
+// for A and B, followed by the control status byte
+func EncodeVGTControl(p ControlPair) []byte {
+	return append(EncodePercentSensors(p), controlStatus(p))
+}
+
+// EncodeFuelPressureControl will encode the commanded and actual rail pressure, and fuel temperature, of fuel systems 1 and 2.
+// See DecodeFuelPressureControl
+func EncodeFuelPressureControl(c FuelPressureControl) []byte {
+	res := make([]byte, 11)
+	for i, s := range []FuelSystemPressure{c.System1, c.System2} {
+		for j, v := range []SensorValue{s.Commanded, s.Actual, s.Temp} {
+			if v.Supported {
+				res[0] |= 1 << uint(i*3+j)
+			}
+		}
+		data := res[1+i*5:]
+		binary.BigEndian.PutUint16(data, scaleUint16(s.Commanded.Value/10))
+		binary.BigEndian.PutUint16(data[2:], scaleUint16(s.Actual.Value/10))
+		data[4] = scaleByte(s.Temp.Value + 40)
+	}
+	return res
+}
+
+// EncodeInjectionPressureControl will encode the commanded and actual injection control pressure for A and B, in kPa
+func EncodeInjectionPressureControl(p ControlPair) []byte {
+	return encodeControlPair(p, 2, func(v float64) uint32 { return scale(v/10, math.MaxUint16) })
+}
+
+// EncodeTurboInletPressure will encode the turbocharger compressor inlet pressure of sensors A and B, in kPa
+func EncodeTurboInletPressure(p SensorPair) []byte {
+	return encodeSensors([]SensorValue{p.A, p.B}, 1, func(v float64) uint32 { return scale(v, math.MaxUint8) })
+}
+
+// EncodeBoostPressureControl will encode the commanded and actual boost pressure for A and B, in kPa, followed by the
+// control status byte
+func EncodeBoostPressureControl(p ControlPair) []byte {
+	res := encodeControlPair(p, 2, func(v float64) uint32 { return scale(v/0.03125, math.MaxUint16) })
+	return append(res, controlStatus(p))
+}
+
+// EncodeExhaustPressure will encode the exhaust pressure of bank 1 and 2, in kPa
+func EncodeExhaustPressure(p BankPair) []byte {
+	return encodeSensors([]SensorValue{p.Bank1, p.Bank2}, 2, func(v float64) uint32 { return scale(v*100, math.MaxUint16) })
+}
+
+// EncodeTurboRPM will encode the RPM of turbochargers A and B
+func EncodeTurboRPM(p SensorPair) []byte {
+	return encodeSensors([]SensorValue{p.A, p.B}, 2, func(v float64) uint32 { return scale(v, math.MaxUint16) })
+}
+
+// EncodeTurboTemp will encode the compressor and turbine inlet and outlet temperatures of a turbocharger, in degrees Celsius
+func EncodeTurboTemp(t TurboTemp) []byte {
+	res := make([]byte, 7)
+	for i, v := range []SensorValue{t.CompressorInlet, t.CompressorOutlet, t.TurbineInlet, t.TurbineOutlet} {
+		if v.Supported {
+			res[0] |= 1 << uint(i)
+		}
+	}
+	res[1] = scaleByte(t.CompressorInlet.Value + 40)
+	res[2] = scaleByte(t.CompressorOutlet.Value + 40)
+	binary.BigEndian.PutUint16(res[3:], scaleUint16((t.TurbineInlet.Value+40)*10))
+	binary.BigEndian.PutUint16(res[5:], scaleUint16((t.TurbineOutlet.Value+40)*10))
+	return res
+}
+
+// encodeTempWords will encode temperatures in degrees Celsius, 2 bytes per sensor
+func encodeTempWords(vals ...SensorValue) []byte {
+	return encodeSensors(vals, 2, func(v float64) uint32 { return scale((v+40)*10, math.MaxUint16) })
+}
+
+// EncodeEGT will encode exhaust gas temperatures of 4 sensors on one bank, in degrees Celsius
+func EncodeEGT(e EGT) []byte {
+	return encodeTempWords(e.Sensors[:]...)
+}
+
+// EncodeDPFTemp will encode the diesel particulate filter inlet and outlet temperature of bank 1 and 2, in degrees Celsius
+func EncodeDPFTemp(t DPFTemp) []byte {
+	return encodeTempWords(t.Bank1Inlet, t.Bank1Outlet, t.Bank2Inlet, t.Bank2Outlet)
+}
+
+// EncodeDPFPressure will encode the diesel particulate filter delta, inlet and outlet pressure, in kPa
+func EncodeDPFPressure(p DPFPressure) []byte {
+	return encodeSensors([]SensorValue{p.Delta, p.Inlet, p.Outlet}, 2, func(v float64) uint32 { return scale(v*100, math.MaxUint16) })
+}
+
+// EncodeEngineRunTime will encode the total engine run time, total idle run time, and total run time with PTO active, in seconds
+func EncodeEngineRunTime(t EngineRunTime) []byte {
+	return encodeSensors([]SensorValue{t.Total, t.Idle, t.PTO}, 4, func(v float64) uint32 { return scale(v, math.MaxUint32) })
+}
+
+// EncodeAECDRunTime will encode the run time of 5 AECDs, with a resolution of 1 second
+func EncodeAECDRunTime(vals []AECDRunTime) []byte {
+	res := make([]byte, 41)
+	for i, v := range vals {
+		if v.Supported {
+			res[0] |= 1 << uint(i)
+		}
+		data := res[1+i*8:]
+		binary.BigEndian.PutUint32(data, scale(v.Timer1.Seconds(), math.MaxUint32))
+		binary.BigEndian.PutUint32(data[4:], scale(v.Timer2.Seconds(), math.MaxUint32))
+	}
+	return res
+}
+
+// EncodeNOxSensor will encode the NOx concentration of bank 1 and 2, sensor 1, in ppm
+func EncodeNOxSensor(p BankPair) []byte {
+	return encodeSensors([]SensorValue{p.Bank1, p.Bank2}, 2, func(v float64) uint32 { return scale(v, math.MaxUint16) })
+}
+
+// EncodeManifoldSurfaceTemp will encode the manifold surface temperature in degrees Celsius
+func EncodeManifoldSurfaceTemp(c int) byte {
+	return scaleByte(float64(c + 40))
+}
+
+// EncodePMSensor will encode the particulate matter concentration of bank 1 and 2, sensor 1, in mg/m³
+func EncodePMSensor(p BankPair) []byte {
+	return encodeSensors([]SensorValue{p.Bank1, p.Bank2}, 2, func(v float64) uint32 { return scale(v/0.0125, math.MaxUint16) })
+}
+
+// EncodeIntakeMAPSensors will encode the intake manifold absolute pressure of sensors A and B, in kPa
+func EncodeIntakeMAPSensors(p SensorPair) []byte {
+	return encodeSensors([]SensorValue{p.A, p.B}, 2, func(v float64) uint32 { return scale(v/0.03125, math.MaxUint16) })
+}
+
+// EncodeFuelRate will encode the response for PIDFuelRate
+func EncodeFuelRate(r FuelRate) []byte {
+	return append(putUint16(scaleUint16(r.Engine/0.02)), putUint16(scaleUint16(r.Vehicle/0.02))...)
+}
+
+// EncodeExhaustFlowRate will encode the engine exhaust flow rate in kg/h
+func EncodeExhaustFlowRate(rate float64) []byte {
+	return putUint16(scaleUint16(rate / 0.2))
+}
+
+// EncodeCylinderFuelRate will encode the cylinder fuel rate in mg/stroke
+func EncodeCylinderFuelRate(rate float64) []byte {
+	return putUint16(scaleUint16(rate * 32))
+}
+
+// EncodeTransmissionGear will encode the response for PIDTransmissionGear
+func EncodeTransmissionGear(g TransmissionGear) []byte {
+	res := make([]byte, 4)
+	if g.Supported {
+		res[0] = 1 << 1
+	}
+	res[1] = byte(scale(float64(g.Gear), 0xf)) << 4
+	binary.BigEndian.PutUint16(res[2:], scaleUint16(g.Ratio*1000))
+	return res
+}
+
+// EncodeDEFDosing will encode the commanded diesel exhaust fluid dosing as a percentage (between 0 and 1)
+func EncodeDEFDosing(v SensorValue) []byte {
+	res := []byte{0, scaleByte(v.Value * 200), 0, 0}
+	if v.Supported {
+		res[0] = 1
+	}
+	return res
+}
+
+// EncodeOdometer will encode the odometer reading in km
+func EncodeOdometer(km float64) []byte {
+	return putUint32(scale(km*10, math.MaxUint32))
+}
@@ -0,0 +1,145 @@
+package mode1
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEncodeDecodeValue(t *testing.T) {
+	cases := []struct {
+		name string
+		v    float64
+		res  float64
+		rt   func(v float64) float64
+	}{
+		{"EngineRPM", 1726.3, 0.25, func(v float64) float64 { return DecodeEngineRPM(EncodeEngineRPM(v)) }},
+		{"MAFRate", 3.5, 0.01, func(v float64) float64 { return DecodeMAFRate(EncodeMAFRate(v)) }},
+		{"FuelTrim", -0.25, 1.0 / 128, func(v float64) float64 { return DecodeFuelTrim(EncodeFuelTrim(v)) }},
+		{"EngineLoad", 0.2, 1.0 / 255, func(v float64) float64 { return DecodeEngineLoad(EncodeEngineLoad(v)) }},
+		{"TimingAdvance", -12.5, 0.5, func(v float64) float64 { return DecodeTimingAdvance(EncodeTimingAdvance(v)) }},
+		{"ECT", -12, 1, func(v float64) float64 { return float64(DecodeECT(EncodeECT(int(v)))) }},
+		{"FuelPressure", 300, 3, func(v float64) float64 { return float64(DecodeFuelPressure(EncodeFuelPressure(int(v)))) }},
+		{"FuelRailPressureRelative", 1000.5, 0.079, func(v float64) float64 {
+			return DecodeFuelRailPressureRelative(EncodeFuelRailPressureRelative(v))
+		}},
+		{"EGRError", -0.5, 1.0 / 128, func(v float64) float64 { return DecodeEGRError(EncodeEGRError(v)) }},
+		{"EvapVaporPressure", -100.3, 0.25, func(v float64) float64 { return DecodeEvapVaporPressure(EncodeEvapVaporPressure(v)) }},
+		{"EvapVaporPressureWide", -3000, 1, func(v float64) float64 {
+			return float64(DecodeEvapVaporPressureWide(EncodeEvapVaporPressureWide(int(v))))
+		}},
+		{"CatalystTemp", 650.2, 0.1, func(v float64) float64 { return DecodeCatalystTemp(EncodeCatalystTemp(v)) }},
+		{"ControlModuleVoltage", 14.1, 0.001, func(v float64) float64 {
+			return DecodeControlModuleVoltage(EncodeControlModuleVoltage(v))
+		}},
+		{"CommandedEquivRatio", 1, 2.0 / 65536, func(v float64) float64 {
+			return DecodeCommandedEquivRatio(EncodeCommandedEquivRatio(v))
+		}},
+		{"FuelInjectionTiming", -5.3, 1.0 / 128, func(v float64) float64 {
+			return DecodeFuelInjectionTiming(EncodeFuelInjectionTiming(v))
+		}},
+		{"EngineFuelRate", 2.35, 0.05, func(v float64) float64 { return DecodeEngineFuelRate(EncodeEngineFuelRate(v)) }},
+		{"TorquePercent", -0.2, 0.01, func(v float64) float64 { return DecodeTorquePercent(EncodeTorquePercent(v)) }},
+		{"ExhaustFlowRate", 120.4, 0.2, func(v float64) float64 { return DecodeExhaustFlowRate(EncodeExhaustFlowRate(v)) }},
+		{"Odometer", 123456.7, 0.1, func(v float64) float64 { return DecodeOdometer(EncodeOdometer(v)) }},
+		{"RunTime", 90, 1, func(v float64) float64 {
+			return DecodeRunTime(EncodeRunTime(time.Duration(v) * time.Second)).Seconds()
+		}},
+		{"Minutes", 125, 1, func(v float64) float64 {
+			return DecodeMinutes(EncodeMinutes(time.Duration(v) * time.Minute)).Minutes()
+		}},
+	}
+	for _, c := range cases {
+		got := c.rt(c.v)
+		if math.Abs(got-c.v) > c.res/2+1e-9 {
+			t.Errorf("%s: got %v; want %v", c.name, got, c.v)
+		}
+	}
+}
+
+func TestEncodeDecodeStruct(t *testing.T) {
+	spark := MonitorStatus{
+		MIL:        true,
+		DTCCount:   3,
+		Misfire:    TestStatus{Available: true, Complete: true},
+		FuelSystem: TestStatus{Available: true},
+		Components: TestStatus{Complete: true},
+		Spark: &MonitorStatusSpark{
+			Catalyst:       TestStatus{Available: true},
+			HeatedCatalyst: TestStatus{Complete: true},
+			EvapSystem:     TestStatus{Complete: true},
+			SecondaryAir:   TestStatus{Complete: true},
+			ACRefrigerant:  TestStatus{Complete: true},
+			O2Sensor:       TestStatus{Available: true, Complete: true},
+			O2SensorHeater: TestStatus{Complete: true},
+			EGRSystem:      TestStatus{Complete: true},
+		},
+	}
+	compression := MonitorStatus{
+		DTCCount:   0x7f,
+		Misfire:    TestStatus{Complete: true},
+		FuelSystem: TestStatus{Complete: true},
+		Components: TestStatus{Complete: true},
+		Compression: &MonitorStatusCompression{
+			NMHCCatalyst:     TestStatus{Available: true},
+			NOxSCRMonitor:    TestStatus{Available: true, Complete: true},
+			BoostPressure:    TestStatus{Complete: true},
+			ExhauseGasSensor: TestStatus{Complete: true},
+			PMFilter:         TestStatus{Available: true},
+			EGRVTT:           TestStatus{Complete: true},
+		},
+	}
+	o2 := O2Present{Bank1: [4]bool{true, true}, Bank2: [4]bool{false, true}}
+	stft := O2STFT{Voltage: 0.45, STFT: 0.25, SensorUsed: true}
+	unused := O2STFT{Voltage: 0.45, STFT: DecodeFuelTrim(0xff)}
+	gear := TransmissionGear{Supported: true, Gear: 3, Ratio: 1.5}
+	turbo := TurboTemp{
+		CompressorInlet: SensorValue{true, 100},
+		TurbineInlet:    SensorValue{true, 600},
+		TurbineOutlet:   SensorValue{true, 12},
+	}
+	fuel := FuelPressureControl{
+		System1: FuelSystemPressure{Commanded: SensorValue{true, 1000}, Actual: SensorValue{true, 990}, Temp: SensorValue{true, 30}},
+		System2: FuelSystemPressure{Temp: SensorValue{false, -40}},
+	}
+	egr := EGRControl{
+		A: EGRValve{Commanded: SensorValue{true, 0.2}, Actual: SensorValue{true, 0.4}, Error: SensorValue{true, 0.5}},
+		B: EGRValve{Commanded: SensorValue{false, 0}, Error: SensorValue{false, -1}},
+	}
+	iat := BankSensors{Bank1: [4]SensorValue{{true, 20}, {true, 25}, {true, 30}}, Bank2: [4]SensorValue{{false, -40}, {true, 22}, {false, -40}}}
+	boost := ControlPair{A: ControlValue{Commanded: SensorValue{true, 150}, Actual: SensorValue{true, 148.5}}}
+
+	cases := []struct {
+		name string
+		want interface{}
+		got  interface{}
+	}{
+		{name: "MonitorStatus/Spark", want: spark, got: DecodeMonitorStatus(EncodeMonitorStatus(spark))},
+		{name: "MonitorStatus/Compression", want: compression, got: DecodeMonitorStatus(EncodeMonitorStatus(compression))},
+		{name: "O2Present", want: o2, got: DecodeO2Present(EncodeO2Present(o2))},
+		{name: "O2STFT", want: stft, got: DecodeO2STFT(EncodeO2STFT(stft))},
+		{name: "O2STFT/Unused", want: unused, got: DecodeO2STFT(EncodeO2STFT(unused))},
+		{name: "AuxInput", want: AuxInputStatus{PTO: true}, got: DecodeAuxInput(EncodeAuxInput(AuxInputStatus{PTO: true}))},
+		{name: "TransmissionGear", want: gear, got: DecodeTransmissionGear(EncodeTransmissionGear(gear))},
+		{name: "TurboTemp", want: turbo, got: DecodeTurboTemp(EncodeTurboTemp(turbo))},
+		{name: "FuelPressureControl", want: fuel, got: DecodeFuelPressureControl(EncodeFuelPressureControl(fuel))},
+		{name: "EGRControl", want: egr, got: DecodeEGRControl(EncodeEGRControl(egr))},
+		{name: "BankTemps", want: iat, got: DecodeBankTemps(EncodeBankTemps(iat, 3))},
+		{name: "BoostPressureControl", want: boost, got: DecodeBoostPressureControl(EncodeBoostPressureControl(boost))},
+	}
+	for _, c := range cases {
+		if !reflect.DeepEqual(c.got, c.want) {
+			t.Errorf("%s: got %+v; want %+v", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestEncodeBankTempsLength(t *testing.T) {
+	var s BankSensors
+	for n, want := range map[int]int{-1: 3, 0: 3, 1: 3, 2: 5, 3: 7, 4: 7, 9: 7} {
+		if got := len(EncodeBankTemps(s, n)); got != want {
+			t.Errorf("EncodeBankTemps(%d): got %d bytes; want %d", n, got, want)
+		}
+	}
+}
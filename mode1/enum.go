@@ -135,3 +135,80 @@ const (
 
 	/* 251-255 Not Available */
 )
+
+// FuelType is the fuel type returned by PIDFuelType
+type FuelType byte
+
+const (
+	// FuelTypeNotAvailable means not available
+	FuelTypeNotAvailable FuelType = 0
+
+	// FuelTypeGasoline means gasoline
+	FuelTypeGasoline FuelType = 1
+
+	// FuelTypeMethanol means methanol
+	FuelTypeMethanol FuelType = 2
+
+	// FuelTypeEthanol means ethanol
+	FuelTypeEthanol FuelType = 3
+
+	// FuelTypeDiesel means diesel
+	FuelTypeDiesel FuelType = 4
+
+	// FuelTypeLPG means liquefied petroleum gas
+	FuelTypeLPG FuelType = 5
+
+	// FuelTypeCNG means compressed natural gas
+	FuelTypeCNG FuelType = 6
+
+	// FuelTypePropane means propane
+	FuelTypePropane FuelType = 7
+
+	// FuelTypeElectric means electric
+	FuelTypeElectric FuelType = 8
+
+	// FuelTypeBifuelGasoline means bifuel running gasoline
+	FuelTypeBifuelGasoline FuelType = 9
+
+	// FuelTypeBifuelMethanol means bifuel running methanol
+	FuelTypeBifuelMethanol FuelType = 10
+
+	// FuelTypeBifuelEthanol means bifuel running ethanol
+	FuelTypeBifuelEthanol FuelType = 11
+
+	// FuelTypeBifuelLPG means bifuel running LPG
+	FuelTypeBifuelLPG FuelType = 12
+
+	// FuelTypeBifuelCNG means bifuel running CNG
+	FuelTypeBifuelCNG FuelType = 13
+
+	// FuelTypeBifuelPropane means bifuel running propane
+	FuelTypeBifuelPropane FuelType = 14
+
+	// FuelTypeBifuelElectric means bifuel running electricity
+	FuelTypeBifuelElectric FuelType = 15
+
+	// FuelTypeBifuelElectricCombustion means bifuel running electric and combustion engine
+	FuelTypeBifuelElectricCombustion FuelType = 16
+
+	// FuelTypeHybridGasoline means hybrid gasoline
+	FuelTypeHybridGasoline FuelType = 17
+
+	// FuelTypeHybridEthanol means hybrid ethanol
+	FuelTypeHybridEthanol FuelType = 18
+
+	// FuelTypeHybridDiesel means hybrid diesel
+	FuelTypeHybridDiesel FuelType = 19
+
+	// FuelTypeHybridElectric means hybrid electric
+	FuelTypeHybridElectric FuelType = 20
+
+	// FuelTypeHybridElectricCombustion means hybrid running electric and combustion engine
+	FuelTypeHybridElectricCombustion FuelType = 21
+
+	// FuelTypeHybridRegenerative means hybrid regenerative
+	FuelTypeHybridRegenerative FuelType = 22
+
+	// FuelTypeBifuelDiesel means bifuel running diesel
+	FuelTypeBifuelDiesel FuelType = 23
+)
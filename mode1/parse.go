@@ -0,0 +1,503 @@
+package mode1
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNotAvailable is returned when the ECU responded, but reports the value as not supported
+var ErrNotAvailable = errors.New("value not available")
+
+// LengthError is returned when a response is too short to decode. It matches ErrShortResponse with errors.Is
+type LengthError struct {
+	// Want is the minimum number of bytes needed
+	Want int
+
+	// Got is the number of bytes in the response
+	Got int
+}
+
+func (e *LengthError) Error() string {
+	return fmt.Sprintf("%s: expected at least %d bytes but got %d", ErrShortResponse, e.Want, e.Got)
+}
+
+// Is will return true if target is ErrShortResponse
+func (e *LengthError) Is(target error) bool {
+	return target == ErrShortResponse
+}
+
+// checkLength will return a LengthError if res is shorter than n bytes
+func checkLength(res []byte, n int) error {
+	if len(res) < n {
+		return &LengthError{Want: n, Got: len(res)}
+	}
+	return nil
+}
+
+// sensors is a decoded multi-sensor PID
+type sensors interface {
+	available() bool
+}
+
+// sensorsAvailable will return ErrNotAvailable if none of the sensors are supported
+func sensorsAvailable(s sensors) error {
+	if s.available() {
+		return nil
+	}
+	return ErrNotAvailable
+}
+
+// ParseSupported is like IsSupported, but will return an error instead of panicking if pid is not between 1 and 32,
+// or res is too short to contain it
+func ParseSupported(res []byte, pid byte) (bool, error) {
+	if pid < 1 || pid > 32 {
+		return false, fmt.Errorf("relative PID %d out of range 1-32", pid)
+	}
+	if err := checkLength(res, int(pid-1)/8+1); err != nil {
+		return false, err
+	}
+	return IsSupported(res, pid), nil
+}
+
+// ParseBankTemps is like DecodeBankTemps, but will return an error instead of panicking if res is shorter than 3 bytes,
+// or ErrNotAvailable if the ECU reports no sensor as supported
+func ParseBankTemps(res []byte) (BankSensors, error) {
+	if err := checkLength(res, 3); err != nil {
+		return BankSensors{}, err
+	}
+	v := DecodeBankTemps(res)
+	return v, sensorsAvailable(v)
+}
+
+// ParseMonitorStatus is like DecodeMonitorStatus, but will return an error instead of panicking if res is too short
+func ParseMonitorStatus(res []byte) (MonitorStatus, error) {
+	if err := checkLength(res, 4); err != nil {
+		return MonitorStatus{}, err
+	}
+	return DecodeMonitorStatus(res), nil
+}
+
+// ParseEngineRPM is like DecodeEngineRPM, but will return an error instead of panicking if res is too short
+func ParseEngineRPM(res []byte) (float64, error) {
+	if err := checkLength(res, 2); err != nil {
+		return 0, err
+	}
+	return DecodeEngineRPM(res), nil
+}
+
+// ParseMAFRate is like DecodeMAFRate, but will return an error instead of panicking if res is too short
+func ParseMAFRate(res []byte) (float64, error) {
+	if err := checkLength(res, 2); err != nil {
+		return 0, err
+	}
+	return DecodeMAFRate(res), nil
+}
+
+// ParseO2STFT is like DecodeO2STFT, but will return an error instead of panicking if res is too short
+func ParseO2STFT(res []byte) (O2STFT, error) {
+	if err := checkLength(res, 2); err != nil {
+		return O2STFT{}, err
+	}
+	return DecodeO2STFT(res), nil
+}
+
+// ParseRunTime is like DecodeRunTime, but will return an error instead of panicking if res is too short
+func ParseRunTime(res []byte) (time.Duration, error) {
+	if err := checkLength(res, 2); err != nil {
+		return 0, err
+	}
+	return DecodeRunTime(res), nil
+}
+
+// ParseDistance is like DecodeDistance, but will return an error instead of panicking if res is too short
+func ParseDistance(res []byte) (int, error) {
+	if err := checkLength(res, 2); err != nil {
+		return 0, err
+	}
+	return DecodeDistance(res), nil
+}
+
+// ParseFuelRailPressureRelative is like DecodeFuelRailPressureRelative, but will return an error instead of panicking if res is too short
+func ParseFuelRailPressureRelative(res []byte) (float64, error) {
+	if err := checkLength(res, 2); err != nil {
+		return 0, err
+	}
+	return DecodeFuelRailPressureRelative(res), nil
+}
+
+// ParseFuelRailPressure is like DecodeFuelRailPressure, but will return an error instead of panicking if res is too short
+func ParseFuelRailPressure(res []byte) (int, error) {
+	if err := checkLength(res, 2); err != nil {
+		return 0, err
+	}
+	return DecodeFuelRailPressure(res), nil
+}
+
+// ParseO2WRVoltage is like DecodeO2WRVoltage, but will return an error instead of panicking if res is too short
+func ParseO2WRVoltage(res []byte) (O2WRVoltage, error) {
+	if err := checkLength(res, 4); err != nil {
+		return O2WRVoltage{}, err
+	}
+	return DecodeO2WRVoltage(res), nil
+}
+
+// ParseEvapVaporPressure is like DecodeEvapVaporPressure, but will return an error instead of panicking if res is too short
+func ParseEvapVaporPressure(res []byte) (float64, error) {
+	if err := checkLength(res, 2); err != nil {
+		return 0, err
+	}
+	return DecodeEvapVaporPressure(res), nil
+}
+
+// ParseO2WRCurrent is like DecodeO2WRCurrent, but will return an error instead of panicking if res is too short
+func ParseO2WRCurrent(res []byte) (O2WRCurrent, error) {
+	if err := checkLength(res, 4); err != nil {
+		return O2WRCurrent{}, err
+	}
+	return DecodeO2WRCurrent(res), nil
+}
+
+// ParseCatalystTemp is like DecodeCatalystTemp, but will return an error instead of panicking if res is too short
+func ParseCatalystTemp(res []byte) (float64, error) {
+	if err := checkLength(res, 2); err != nil {
+		return 0, err
+	}
+	return DecodeCatalystTemp(res), nil
+}
+
+// ParseControlModuleVoltage is like DecodeControlModuleVoltage, but will return an error instead of panicking if res is too short
+func ParseControlModuleVoltage(res []byte) (float64, error) {
+	if err := checkLength(res, 2); err != nil {
+		return 0, err
+	}
+	return DecodeControlModuleVoltage(res), nil
+}
+
+// ParseAbsoluteLoad is like DecodeAbsoluteLoad, but will return an error instead of panicking if res is too short
+func ParseAbsoluteLoad(res []byte) (float64, error) {
+	if err := checkLength(res, 2); err != nil {
+		return 0, err
+	}
+	return DecodeAbsoluteLoad(res), nil
+}
+
+// ParseCommandedEquivRatio is like DecodeCommandedEquivRatio, but will return an error instead of panicking if res is too short
+func ParseCommandedEquivRatio(res []byte) (float64, error) {
+	if err := checkLength(res, 2); err != nil {
+		return 0, err
+	}
+	return DecodeCommandedEquivRatio(res), nil
+}
+
+// ParseMinutes is like DecodeMinutes, but will return an error instead of panicking if res is too short
+func ParseMinutes(res []byte) (time.Duration, error) {
+	if err := checkLength(res, 2); err != nil {
+		return 0, err
+	}
+	return DecodeMinutes(res), nil
+}
+
+// ParseMaxValues is like DecodeMaxValues, but will return an error instead of panicking if res is too short
+func ParseMaxValues(res []byte) (MaxValues, error) {
+	if err := checkLength(res, 4); err != nil {
+		return MaxValues{}, err
+	}
+	return DecodeMaxValues(res), nil
+}
+
+// ParseMaxMAFRate is like DecodeMaxMAFRate, but will return an error instead of panicking if res is too short
+func ParseMaxMAFRate(res []byte) (int, error) {
+	if err := checkLength(res, 1); err != nil {
+		return 0, err
+	}
+	return DecodeMaxMAFRate(res), nil
+}
+
+// ParseEvapPressureAbsolute is like DecodeEvapPressureAbsolute, but will return an error instead of panicking if res is too short
+func ParseEvapPressureAbsolute(res []byte) (float64, error) {
+	if err := checkLength(res, 2); err != nil {
+		return 0, err
+	}
+	return DecodeEvapPressureAbsolute(res), nil
+}
+
+// ParseEvapVaporPressureWide is like DecodeEvapVaporPressureWide, but will return an error instead of panicking if res is too short
+func ParseEvapVaporPressureWide(res []byte) (int, error) {
+	if err := checkLength(res, 2); err != nil {
+		return 0, err
+	}
+	return DecodeEvapVaporPressureWide(res), nil
+}
+
+// ParseFuelInjectionTiming is like DecodeFuelInjectionTiming, but will return an error instead of panicking if res is too short
+func ParseFuelInjectionTiming(res []byte) (float64, error) {
+	if err := checkLength(res, 2); err != nil {
+		return 0, err
+	}
+	return DecodeFuelInjectionTiming(res), nil
+}
+
+// ParseEngineFuelRate is like DecodeEngineFuelRate, but will return an error instead of panicking if res is too short
+func ParseEngineFuelRate(res []byte) (float64, error) {
+	if err := checkLength(res, 2); err != nil {
+		return 0, err
+	}
+	return DecodeEngineFuelRate(res), nil
+}
+
+// ParseReferenceTorque is like DecodeReferenceTorque, but will return an error instead of panicking if res is too short
+func ParseReferenceTorque(res []byte) (int, error) {
+	if err := checkLength(res, 2); err != nil {
+		return 0, err
+	}
+	return DecodeReferenceTorque(res), nil
+}
+
+// ParseTorqueData is like DecodeTorqueData, but will return an error instead of panicking if res is too short
+func ParseTorqueData(res []byte) (TorqueData, error) {
+	if err := checkLength(res, 5); err != nil {
+		return TorqueData{}, err
+	}
+	return DecodeTorqueData(res), nil
+}
+
+// ParseAuxIO is like DecodeAuxIO, but will return an error instead of panicking if res is too short
+func ParseAuxIO(res []byte) (AuxIO, error) {
+	if err := checkLength(res, 2); err != nil {
+		return AuxIO{}, err
+	}
+	return DecodeAuxIO(res), nil
+}
+
+// ParseMAFSensors is like DecodeMAFSensors, but will return an error instead of panicking if res is too short, or ErrNotAvailable if the ECU reports no sensor as supported
+func ParseMAFSensors(res []byte) (SensorPair, error) {
+	if err := checkLength(res, 5); err != nil {
+		return SensorPair{}, err
+	}
+	v := DecodeMAFSensors(res)
+	return v, sensorsAvailable(v)
+}
+
+// ParseECTSensors is like DecodeECTSensors, but will return an error instead of panicking if res is too short, or ErrNotAvailable if the ECU reports no sensor as supported
+func ParseECTSensors(res []byte) (SensorPair, error) {
+	if err := checkLength(res, 3); err != nil {
+		return SensorPair{}, err
+	}
+	v := DecodeECTSensors(res)
+	return v, sensorsAvailable(v)
+}
+
+// ParseEGRControl is like DecodeEGRControl, but will return an error instead of panicking if res is too short, or ErrNotAvailable if the ECU reports no sensor as supported
+func ParseEGRControl(res []byte) (EGRControl, error) {
+	if err := checkLength(res, 7); err != nil {
+		return EGRControl{}, err
+	}
+	v := DecodeEGRControl(res)
+	return v, sensorsAvailable(v)
+}
+
+// ParsePercentSensors is like DecodePercentSensors, but will return an error instead of panicking if res is too short, or ErrNotAvailable if the ECU reports no sensor as supported
+func ParsePercentSensors(res []byte) (ControlPair, error) {
+	if err := checkLength(res, 5); err != nil {
+		return ControlPair{}, err
+	}
+	v := DecodePercentSensors(res)
+	return v, sensorsAvailable(v)
+}
+
+// ParseFuelPressureControl is like DecodeFuelPressureControl, but will return an error instead of panicking if res is too short, or ErrNotAvailable if the ECU reports no sensor as supported
+func ParseFuelPressureControl(res []byte) (FuelPressureControl, error) {
+	if err := checkLength(res, 11); err != nil {
+		return FuelPressureControl{}, err
+	}
+	v := DecodeFuelPressureControl(res)
+	return v, sensorsAvailable(v)
+}
+
+// ParseInjectionPressureControl is like DecodeInjectionPressureControl, but will return an error instead of panicking if res is too short, or ErrNotAvailable if the ECU reports no sensor as supported
+func ParseInjectionPressureControl(res []byte) (ControlPair, error) {
+	if err := checkLength(res, 9); err != nil {
+		return ControlPair{}, err
+	}
+	v := DecodeInjectionPressureControl(res)
+	return v, sensorsAvailable(v)
+}
+
+// ParseTurboInletPressure is like DecodeTurboInletPressure, but will return an error instead of panicking if res is too short, or ErrNotAvailable if the ECU reports no sensor as supported
+func ParseTurboInletPressure(res []byte) (SensorPair, error) {
+	if err := checkLength(res, 3); err != nil {
+		return SensorPair{}, err
+	}
+	v := DecodeTurboInletPressure(res)
+	return v, sensorsAvailable(v)
+}
+
+// ParseBoostPressureControl is like DecodeBoostPressureControl, but will return an error instead of panicking if res is too short, or ErrNotAvailable if the ECU reports no sensor as supported
+func ParseBoostPressureControl(res []byte) (ControlPair, error) {
+	if err := checkLength(res, 9); err != nil {
+		return ControlPair{}, err
+	}
+	v := DecodeBoostPressureControl(res)
+	return v, sensorsAvailable(v)
+}
+
+// ParseExhaustPressure is like DecodeExhaustPressure, but will return an error instead of panicking if res is too short, or ErrNotAvailable if the ECU reports no sensor as supported
+func ParseExhaustPressure(res []byte) (BankPair, error) {
+	if err := checkLength(res, 5); err != nil {
+		return BankPair{}, err
+	}
+	v := DecodeExhaustPressure(res)
+	return v, sensorsAvailable(v)
+}
+
+// ParseTurboRPM is like DecodeTurboRPM, but will return an error instead of panicking if res is too short, or ErrNotAvailable if the ECU reports no sensor as supported
+func ParseTurboRPM(res []byte) (SensorPair, error) {
+	if err := checkLength(res, 5); err != nil {
+		return SensorPair{}, err
+	}
+	v := DecodeTurboRPM(res)
+	return v, sensorsAvailable(v)
+}
+
+// ParseTurboTemp is like DecodeTurboTemp, but will return an error instead of panicking if res is too short, or ErrNotAvailable if the ECU reports no sensor as supported
+func ParseTurboTemp(res []byte) (TurboTemp, error) {
+	if err := checkLength(res, 7); err != nil {
+		return TurboTemp{}, err
+	}
+	v := DecodeTurboTemp(res)
+	return v, sensorsAvailable(v)
+}
+
+// ParseEGT is like DecodeEGT, but will return an error instead of panicking if res is too short, or ErrNotAvailable if the ECU reports no sensor as supported
+func ParseEGT(res []byte) (EGT, error) {
+	if err := checkLength(res, 9); err != nil {
+		return EGT{}, err
+	}
+	v := DecodeEGT(res)
+	return v, sensorsAvailable(v)
+}
+
+// ParseDPFTemp is like DecodeDPFTemp, but will return an error instead of panicking if res is too short, or ErrNotAvailable if the ECU reports no sensor as supported
+func ParseDPFTemp(res []byte) (DPFTemp, error) {
+	if err := checkLength(res, 9); err != nil {
+		return DPFTemp{}, err
+	}
+	v := DecodeDPFTemp(res)
+	return v, sensorsAvailable(v)
+}
+
+// ParseDPFPressure is like DecodeDPFPressure, but will return an error instead of panicking if res is too short, or ErrNotAvailable if the ECU reports no sensor as supported
+func ParseDPFPressure(res []byte) (DPFPressure, error) {
+	if err := checkLength(res, 7); err != nil {
+		return DPFPressure{}, err
+	}
+	v := DecodeDPFPressure(res)
+	return v, sensorsAvailable(v)
+}
+
+// ParseEngineRunTime is like DecodeEngineRunTime, but will return an error instead of panicking if res is too short, or ErrNotAvailable if the ECU reports no sensor as supported
+func ParseEngineRunTime(res []byte) (EngineRunTime, error) {
+	if err := checkLength(res, 13); err != nil {
+		return EngineRunTime{}, err
+	}
+	v := DecodeEngineRunTime(res)
+	return v, sensorsAvailable(v)
+}
+
+// ParseAECDRunTime is like DecodeAECDRunTime, but will return an error instead of panicking if res is too short, or ErrNotAvailable if the ECU reports no AECD as supported
+func ParseAECDRunTime(res []byte) ([]AECDRunTime, error) {
+	if err := checkLength(res, 41); err != nil {
+		return nil, err
+	}
+	v := DecodeAECDRunTime(res)
+	for _, t := range v {
+		if t.Supported {
+			return v, nil
+		}
+	}
+	return v, ErrNotAvailable
+}
+
+// ParseNOxSensor is like DecodeNOxSensor, but will return an error instead of panicking if res is too short, or ErrNotAvailable if the ECU reports no sensor as supported
+func ParseNOxSensor(res []byte) (BankPair, error) {
+	if err := checkLength(res, 5); err != nil {
+		return BankPair{}, err
+	}
+	v := DecodeNOxSensor(res)
+	return v, sensorsAvailable(v)
+}
+
+// ParsePMSensor is like DecodePMSensor, but will return an error instead of panicking if res is too short, or ErrNotAvailable if the ECU reports no sensor as supported
+func ParsePMSensor(res []byte) (BankPair, error) {
+	if err := checkLength(res, 5); err != nil {
+		return BankPair{}, err
+	}
+	v := DecodePMSensor(res)
+	return v, sensorsAvailable(v)
+}
+
+// ParseIntakeMAPSensors is like DecodeIntakeMAPSensors, but will return an error instead of panicking if res is too short, or ErrNotAvailable if the ECU reports no sensor as supported
+func ParseIntakeMAPSensors(res []byte) (SensorPair, error) {
+	if err := checkLength(res, 5); err != nil {
+		return SensorPair{}, err
+	}
+	v := DecodeIntakeMAPSensors(res)
+	return v, sensorsAvailable(v)
+}
+
+// ParseFuelRate is like DecodeFuelRate, but will return an error instead of panicking if res is too short
+func ParseFuelRate(res []byte) (FuelRate, error) {
+	if err := checkLength(res, 4); err != nil {
+		return FuelRate{}, err
+	}
+	return DecodeFuelRate(res), nil
+}
+
+// ParseExhaustFlowRate is like DecodeExhaustFlowRate, but will return an error instead of panicking if res is too short
+func ParseExhaustFlowRate(res []byte) (float64, error) {
+	if err := checkLength(res, 2); err != nil {
+		return 0, err
+	}
+	return DecodeExhaustFlowRate(res), nil
+}
+
+// ParseCylinderFuelRate is like DecodeCylinderFuelRate, but will return an error instead of panicking if res is too short
+func ParseCylinderFuelRate(res []byte) (float64, error) {
+	if err := checkLength(res, 2); err != nil {
+		return 0, err
+	}
+	return DecodeCylinderFuelRate(res), nil
+}
+
+// ParseTransmissionGear is like DecodeTransmissionGear, but will return an error instead of panicking if res is too short, or ErrNotAvailable if the ECU reports the value as not supported
+func ParseTransmissionGear(res []byte) (TransmissionGear, error) {
+	if err := checkLength(res, 4); err != nil {
+		return TransmissionGear{}, err
+	}
+	v := DecodeTransmissionGear(res)
+	if !v.Supported {
+		return v, ErrNotAvailable
+	}
+	return v, nil
+}
+
+// ParseDEFDosing is like DecodeDEFDosing, but will return an error instead of panicking if res is too short, or ErrNotAvailable if the ECU reports the value as not supported
+func ParseDEFDosing(res []byte) (SensorValue, error) {
+	if err := checkLength(res, 2); err != nil {
+		return SensorValue{}, err
+	}
+	v := DecodeDEFDosing(res)
+	if !v.Supported {
+		return v, ErrNotAvailable
+	}
+	return v, nil
+}
+
+// ParseOdometer is like DecodeOdometer, but will return an error instead of panicking if res is too short
+func ParseOdometer(res []byte) (float64, error) {
+	if err := checkLength(res, 4); err != nil {
+		return 0, err
+	}
+	return DecodeOdometer(res), nil
+}
@@ -0,0 +1,29 @@
+package mode1
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseSensors(t *testing.T) {
+	parsers := []struct {
+		name  string
+		parse func(res []byte) error
+		res   []byte
+	}{
+		{"ECTSensors", func(res []byte) error { _, err := ParseECTSensors(res); return err }, EncodeECTSensors(SensorPair{A: SensorValue{Supported: true}})},
+		{"DPFTemp", func(res []byte) error { _, err := ParseDPFTemp(res); return err }, EncodeDPFTemp(DPFTemp{Bank2Outlet: SensorValue{Supported: true}})},
+	}
+	for _, p := range parsers {
+		if err := p.parse(p.res); err != nil {
+			t.Errorf("%s: %v", p.name, err)
+		}
+		if err := p.parse(p.res[:len(p.res)-1]); !errors.Is(err, ErrShortResponse) {
+			t.Errorf("%s: got %v for a short response; want ErrShortResponse", p.name, err)
+		}
+		none := append([]byte{0}, p.res[1:]...)
+		if err := p.parse(none); err != ErrNotAvailable {
+			t.Errorf("%s: got %v with no sensors supported; want ErrNotAvailable", p.name, err)
+		}
+	}
+}
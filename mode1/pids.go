@@ -99,4 +99,421 @@ const (
 
 	// PIDRunTime will request the run time since engine start in seconds. Use with DecodeRunTime
 	PIDRunTime byte = 0x1f
+
+	// PIDSupport2 will return supported PIDs from 0x21 to 0x40
+	PIDSupport2 byte = 0x20
+
+	// PIDDistanceMIL will request the distance traveled with the MIL on. Use with DecodeDistance
+	PIDDistanceMIL byte = 0x21
+
+	// PIDFuelRailPressureRelative will request the fuel rail pressure relative to manifold vacuum. Use with DecodeFuelRailPressureRelative
+	PIDFuelRailPressureRelative byte = 0x22
+
+	// PIDFuelRailPressure will request the fuel rail gauge pressure (diesel, or gasoline direct injection). Use with DecodeFuelRailPressure
+	PIDFuelRailPressure byte = 0x23
+
+	// PIDO2WRVoltage1 requests the equivalence ratio and voltage for wide-band oxygen sensor 1. Use with DecodeO2WRVoltage
+	PIDO2WRVoltage1 byte = 0x24
+
+	// PIDO2WRVoltage2 requests the equivalence ratio and voltage for wide-band oxygen sensor 2. Use with DecodeO2WRVoltage
+	PIDO2WRVoltage2 byte = 0x25
+
+	// PIDO2WRVoltage3 requests the equivalence ratio and voltage for wide-band oxygen sensor 3. Use with DecodeO2WRVoltage
+	PIDO2WRVoltage3 byte = 0x26
+
+	// PIDO2WRVoltage4 requests the equivalence ratio and voltage for wide-band oxygen sensor 4. Use with DecodeO2WRVoltage
+	PIDO2WRVoltage4 byte = 0x27
+
+	// PIDO2WRVoltage5 requests the equivalence ratio and voltage for wide-band oxygen sensor 5. Use with DecodeO2WRVoltage
+	PIDO2WRVoltage5 byte = 0x28
+
+	// PIDO2WRVoltage6 requests the equivalence ratio and voltage for wide-band oxygen sensor 6. Use with DecodeO2WRVoltage
+	PIDO2WRVoltage6 byte = 0x29
+
+	// PIDO2WRVoltage7 requests the equivalence ratio and voltage for wide-band oxygen sensor 7. Use with DecodeO2WRVoltage
+	PIDO2WRVoltage7 byte = 0x2a
+
+	// PIDO2WRVoltage8 requests the equivalence ratio and voltage for wide-band oxygen sensor 8. Use with DecodeO2WRVoltage
+	PIDO2WRVoltage8 byte = 0x2b
+
+	// PIDCommandedEGR will request the commanded EGR (exhaust gas recirculation). Use with DecodeCommandedEGR
+	PIDCommandedEGR byte = 0x2c
+
+	// PIDEGRError will request the EGR error, relative to the commanded EGR. Use with DecodeEGRError
+	PIDEGRError byte = 0x2d
+
+	// PIDEvapPurge will request the commanded evaporative purge. Use with DecodeEvapPurge
+	PIDEvapPurge byte = 0x2e
+
+	// PIDFuelTankLevel will request the fuel tank level input. Use with DecodeFuelTankLevel
+	PIDFuelTankLevel byte = 0x2f
+
+	// PIDWarmUps will request the number of warm-ups since codes were cleared. Use with DecodeWarmUps
+	PIDWarmUps byte = 0x30
+
+	// PIDDistanceSinceClear will request the distance traveled since codes were cleared. Use with DecodeDistance
+	PIDDistanceSinceClear byte = 0x31
+
+	// PIDEvapVaporPressure will request the evaporative system vapor pressure. Use with DecodeEvapVaporPressure
+	PIDEvapVaporPressure byte = 0x32
+
+	// PIDBarometricPressure will request the absolute barometric pressure. Use with DecodeBarometricPressure
+	PIDBarometricPressure byte = 0x33
+
+	// PIDO2WRCurrent1 requests the equivalence ratio and current for wide-band oxygen sensor 1. Use with DecodeO2WRCurrent
+	PIDO2WRCurrent1 byte = 0x34
+
+	// PIDO2WRCurrent2 requests the equivalence ratio and current for wide-band oxygen sensor 2. Use with DecodeO2WRCurrent
+	PIDO2WRCurrent2 byte = 0x35
+
+	// PIDO2WRCurrent3 requests the equivalence ratio and current for wide-band oxygen sensor 3. Use with DecodeO2WRCurrent
+	PIDO2WRCurrent3 byte = 0x36
+
+	// PIDO2WRCurrent4 requests the equivalence ratio and current for wide-band oxygen sensor 4. Use with DecodeO2WRCurrent
+	PIDO2WRCurrent4 byte = 0x37
+
+	// PIDO2WRCurrent5 requests the equivalence ratio and current for wide-band oxygen sensor 5. Use with DecodeO2WRCurrent
+	PIDO2WRCurrent5 byte = 0x38
+
+	// PIDO2WRCurrent6 requests the equivalence ratio and current for wide-band oxygen sensor 6. Use with DecodeO2WRCurrent
+	PIDO2WRCurrent6 byte = 0x39
+
+	// PIDO2WRCurrent7 requests the equivalence ratio and current for wide-band oxygen sensor 7. Use with DecodeO2WRCurrent
+	PIDO2WRCurrent7 byte = 0x3a
+
+	// PIDO2WRCurrent8 requests the equivalence ratio and current for wide-band oxygen sensor 8. Use with DecodeO2WRCurrent
+	PIDO2WRCurrent8 byte = 0x3b
+
+	// PIDCatalystTempB1S1 will request the catalyst temperature of bank 1, sensor 1. Use with DecodeCatalystTemp
+	PIDCatalystTempB1S1 byte = 0x3c
+
+	// PIDCatalystTempB2S1 will request the catalyst temperature of bank 2, sensor 1. Use with DecodeCatalystTemp
+	PIDCatalystTempB2S1 byte = 0x3d
+
+	// PIDCatalystTempB1S2 will request the catalyst temperature of bank 1, sensor 2. Use with DecodeCatalystTemp
+	PIDCatalystTempB1S2 byte = 0x3e
+
+	// PIDCatalystTempB2S2 will request the catalyst temperature of bank 2, sensor 2. Use with DecodeCatalystTemp
+	PIDCatalystTempB2S2 byte = 0x3f
+
+	// PIDSupport3 will return supported PIDs from 0x41 to 0x60
+	PIDSupport3 byte = 0x40
+
+	// PIDMonitorStatusDriveCycle is used to monitor status this drive cycle. Use with DecodeMonitorStatus (MIL and DTCCount are not reported)
+	PIDMonitorStatusDriveCycle byte = 0x41
+
+	// PIDControlModuleVoltage will request the control module voltage. Use with DecodeControlModuleVoltage
+	PIDControlModuleVoltage byte = 0x42
+
+	// PIDAbsoluteLoad will request the absolute load value. Use with DecodeAbsoluteLoad
+	PIDAbsoluteLoad byte = 0x43
+
+	// PIDCommandedEquivRatio will request the commanded fuel-air equivalence ratio. Use with DecodeCommandedEquivRatio
+	PIDCommandedEquivRatio byte = 0x44
+
+	// PIDRelativeThrottlePos will request the relative throttle position. Use with DecodeThrottlePos
+	PIDRelativeThrottlePos byte = 0x45
+
+	// PIDAmbientAirTemp will request the ambient air temperature. Use with DecodeAmbientAirTemp
+	PIDAmbientAirTemp byte = 0x46
+
+	// PIDAbsoluteThrottlePosB will request absolute throttle position B. Use with DecodeThrottlePos
+	PIDAbsoluteThrottlePosB byte = 0x47
+
+	// PIDAbsoluteThrottlePosC will request absolute throttle position C. Use with DecodeThrottlePos
+	PIDAbsoluteThrottlePosC byte = 0x48
+
+	// PIDAccelPedalPosD will request accelerator pedal position D. Use with DecodePedalPos
+	PIDAccelPedalPosD byte = 0x49
+
+	// PIDAccelPedalPosE will request accelerator pedal position E. Use with DecodePedalPos
+	PIDAccelPedalPosE byte = 0x4a
+
+	// PIDAccelPedalPosF will request accelerator pedal position F. Use with DecodePedalPos
+	PIDAccelPedalPosF byte = 0x4b
+
+	// PIDCommandedThrottleActuator will request the commanded throttle actuator. Use with DecodeCommandedThrottleActuator
+	PIDCommandedThrottleActuator byte = 0x4c
+
+	// PIDTimeMIL will request the time run with the MIL on. Use with DecodeMinutes
+	PIDTimeMIL byte = 0x4d
+
+	// PIDTimeSinceClear will request the time since trouble codes were cleared. Use with DecodeMinutes
+	PIDTimeSinceClear byte = 0x4e
+
+	// PIDMaxValues will request the maximum values for equivalence ratio, O2 sensor voltage and current, and intake MAP. Use with DecodeMaxValues
+	PIDMaxValues byte = 0x4f
+
+	// PIDMaxMAFRate will request the maximum value for the MAF rate. Use with DecodeMaxMAFRate
+	PIDMaxMAFRate byte = 0x50
+
+	// PIDFuelType will request the fuel type. Can be matched against FuelType values
+	PIDFuelType byte = 0x51
+
+	// PIDEthanolPercent will request the ethanol fuel percentage. Use with DecodeEthanolPercent
+	PIDEthanolPercent byte = 0x52
+
+	// PIDEvapPressureAbsolute will request the absolute evaporative system vapor pressure. Use with DecodeEvapPressureAbsolute
+	PIDEvapPressureAbsolute byte = 0x53
+
+	// PIDEvapVaporPressureWide will request the evaporative system vapor pressure with a wider range than PIDEvapVaporPressure. Use with DecodeEvapVaporPressureWide
+	PIDEvapVaporPressureWide byte = 0x54
+
+	// PIDSecondaryO2STTBank13 will request the short term secondary oxygen sensor trim for banks 1 and 3. Use with DecodeFuelTrim for each byte
+	PIDSecondaryO2STTBank13 byte = 0x55
+
+	// PIDSecondaryO2LTTBank13 will request the long term secondary oxygen sensor trim for banks 1 and 3. Use with DecodeFuelTrim for each byte
+	PIDSecondaryO2LTTBank13 byte = 0x56
+
+	// PIDSecondaryO2STTBank24 will request the short term secondary oxygen sensor trim for banks 2 and 4. Use with DecodeFuelTrim for each byte
+	PIDSecondaryO2STTBank24 byte = 0x57
+
+	// PIDSecondaryO2LTTBank24 will request the long term secondary oxygen sensor trim for banks 2 and 4. Use with DecodeFuelTrim for each byte
+	PIDSecondaryO2LTTBank24 byte = 0x58
+
+	// PIDFuelRailPressureAbsolute will request the absolute fuel rail pressure. Use with DecodeFuelRailPressure
+	PIDFuelRailPressureAbsolute byte = 0x59
+
+	// PIDRelativePedalPos will request the relative accelerator pedal position. Use with DecodePedalPos
+	PIDRelativePedalPos byte = 0x5a
+
+	// PIDHybridBatteryLife will request the hybrid battery pack remaining life. Use with DecodeHybridBatteryLife
+	PIDHybridBatteryLife byte = 0x5b
+
+	// PIDEngineOilTemp will request the engine oil temperature. Use with DecodeEngineOilTemp
+	PIDEngineOilTemp byte = 0x5c
+
+	// PIDFuelInjectionTiming will request the fuel injection timing. Use with DecodeFuelInjectionTiming
+	PIDFuelInjectionTiming byte = 0x5d
+
+	// PIDEngineFuelRate will request the engine fuel rate. Use with DecodeEngineFuelRate
+	PIDEngineFuelRate byte = 0x5e
+
+	// PIDEmissionRequirements will request the emission requirements the vehicle is designed to. Response is bit encoded
+	PIDEmissionRequirements byte = 0x5f
+
+	// PIDSupport4 will return supported PIDs from 0x61 to 0x80
+	PIDSupport4 byte = 0x60
+
+	// PIDDriverDemandTorque will request the driver's demand engine percent torque. Use with DecodeTorquePercent
+	PIDDriverDemandTorque byte = 0x61
+
+	// PIDActualTorque will request the actual engine percent torque. Use with DecodeTorquePercent
+	PIDActualTorque byte = 0x62
+
+	// PIDReferenceTorque will request the engine reference torque. Use with DecodeReferenceTorque
+	PIDReferenceTorque byte = 0x63
+
+	// PIDTorqueData will request the engine percent torque data at idle and 4 engine points. Use with DecodeTorqueData
+	PIDTorqueData byte = 0x64
+
+	// PIDAuxIO will request the auxiliary input/output status. Use with DecodeAuxIO
+	PIDAuxIO byte = 0x65
+
+	// PIDMAFSensors will request the mass air flow of sensors A and B. Use with DecodeMAFSensors
+	PIDMAFSensors byte = 0x66
+
+	// PIDECTSensors will request the engine coolant temperature of sensors 1 and 2. Use with DecodeECTSensors
+	PIDECTSensors byte = 0x67
+
+	// PIDIATSensors will request the intake air temperature of bank 1 and 2, sensors 1 to 3. Use with DecodeBankTemps
+	PIDIATSensors byte = 0x68
+
+	// PIDEGRControl will request the commanded and actual EGR duty cycle, and EGR error, for EGR A and B. Use with DecodeEGRControl
+	PIDEGRControl byte = 0x69
+
+	// PIDIntakeAirFlowControl will request the commanded and relative diesel intake air flow control position for A and B. Use with DecodePercentSensors
+	PIDIntakeAirFlowControl byte = 0x6a
+
+	// PIDEGRTemp will request the exhaust gas recirculation temperature of bank 1 and 2, sensors 1 and 2. Use with DecodeBankTemps
+	PIDEGRTemp byte = 0x6b
+
+	// PIDThrottleActuatorControl will request the commanded throttle actuator and relative throttle position for A and B. Use with DecodePercentSensors
+	PIDThrottleActuatorControl byte = 0x6c
+
+	// PIDFuelPressureControl will request the commanded and actual fuel rail pressure and fuel temperature for fuel systems 1 and 2. Use with DecodeFuelPressureControl
+	PIDFuelPressureControl byte = 0x6d
+
+	// PIDInjectionPressureControl will request the commanded and actual injection control pressure for A and B. Use with DecodeInjectionPressureControl
+	PIDInjectionPressureControl byte = 0x6e
+
+	// PIDTurboInletPressure will request the turbocharger compressor inlet pressure of sensors A and B. Use with DecodeTurboInletPressure
+	PIDTurboInletPressure byte = 0x6f
+
+	// PIDBoostPressureControl will request the commanded and actual boost pressure for A and B. Use with DecodeBoostPressureControl
+	PIDBoostPressureControl byte = 0x70
+
+	// PIDVGTControl will request the commanded and actual variable geometry turbo position for A and B. Use with DecodePercentSensors
+	PIDVGTControl byte = 0x71
+
+	// PIDWastegateControl will request the commanded and actual wastegate position for A and B. Use with DecodePercentSensors
+	PIDWastegateControl byte = 0x72
+
+	// PIDExhaustPressure will request the exhaust pressure of bank 1 and 2. Use with DecodeExhaustPressure
+	PIDExhaustPressure byte = 0x73
+
+	// PIDTurboRPM will request the RPM of turbochargers A and B. Use with DecodeTurboRPM
+	PIDTurboRPM byte = 0x74
+
+	// PIDTurboTempA will request the compressor and turbine inlet and outlet temperatures of turbocharger A. Use with DecodeTurboTemp
+	PIDTurboTempA byte = 0x75
+
+	// PIDTurboTempB will request the compressor and turbine inlet and outlet temperatures of turbocharger B. Use with DecodeTurboTemp
+	PIDTurboTempB byte = 0x76
+
+	// PIDChargeAirCoolerTemp will request the charge air cooler temperature of bank 1 and 2, sensors 1 and 2. Use with DecodeBankTemps
+	PIDChargeAirCoolerTemp byte = 0x77
+
+	// PIDEGTBank1 will request the exhaust gas temperature of bank 1, sensors 1 to 4. Use with DecodeEGT
+	PIDEGTBank1 byte = 0x78
+
+	// PIDEGTBank2 will request the exhaust gas temperature of bank 2, sensors 1 to 4. Use with DecodeEGT
+	PIDEGTBank2 byte = 0x79
+
+	// PIDDPFBank1 will request the diesel particulate filter delta, inlet and outlet pressure of bank 1. Use with DecodeDPFPressure
+	PIDDPFBank1 byte = 0x7a
+
+	// PIDDPFBank2 will request the diesel particulate filter delta, inlet and outlet pressure of bank 2. Use with DecodeDPFPressure
+	PIDDPFBank2 byte = 0x7b
+
+	// PIDDPFTemp will request the diesel particulate filter inlet and outlet temperature of bank 1 and 2. Use with DecodeDPFTemp
+	PIDDPFTemp byte = 0x7c
+
+	// PIDNOxNTEStatus will request the NOx NTE (not-to-exceed) control area status. Response is bit encoded
+	PIDNOxNTEStatus byte = 0x7d
+
+	// PIDPMNTEStatus will request the PM NTE (not-to-exceed) control area status. Response is bit encoded
+	PIDPMNTEStatus byte = 0x7e
+
+	// PIDEngineRunTime will request the total engine run time, idle run time and run time with PTO active. Use with DecodeEngineRunTime
+	PIDEngineRunTime byte = 0x7f
+
+	// PIDSupport5 will return supported PIDs from 0x81 to 0xA0
+	PIDSupport5 byte = 0x80
+
+	// PIDAECDRunTime1 will request the engine run time for AECD (auxiliary emission control device) #1 to #5. Use with DecodeAECDRunTime
+	PIDAECDRunTime1 byte = 0x81
+
+	// PIDAECDRunTime2 will request the engine run time for AECD #6 to #10. Use with DecodeAECDRunTime
+	PIDAECDRunTime2 byte = 0x82
+
+	// PIDNOxSensor will request the NOx concentration of bank 1 and 2, sensor 1. Use with DecodeNOxSensor
+	PIDNOxSensor byte = 0x83
+
+	// PIDManifoldSurfaceTemp will request the manifold surface temperature. Use with DecodeManifoldSurfaceTemp
+	PIDManifoldSurfaceTemp byte = 0x84
+
+	// PIDNOxReagentSystem will request the NOx reagent system status. Response is not decoded
+	PIDNOxReagentSystem byte = 0x85
+
+	// PIDPMSensor will request the particulate matter concentration of bank 1 and 2, sensor 1. Use with DecodePMSensor
+	PIDPMSensor byte = 0x86
+
+	// PIDIntakeMAPSensors will request the intake manifold absolute pressure of sensors A and B. Use with DecodeIntakeMAPSensors
+	PIDIntakeMAPSensors byte = 0x87
+
+	// PIDSCRInducement will request the SCR (selective catalytic reduction) inducement system status. Response is not decoded
+	PIDSCRInducement byte = 0x88
+
+	// PIDAECDRunTime3 will request the engine run time for AECD #11 to #15. Use with DecodeAECDRunTime
+	PIDAECDRunTime3 byte = 0x89
+
+	// PIDAECDRunTime4 will request the engine run time for AECD #16 to #20. Use with DecodeAECDRunTime
+	PIDAECDRunTime4 byte = 0x8a
+
+	// PIDDieselAftertreatment will request the diesel aftertreatment status. Response is not decoded
+	PIDDieselAftertreatment byte = 0x8b
+
+	// PIDO2SensorWide will request wide range oxygen sensor data. Response is not decoded
+	PIDO2SensorWide byte = 0x8c
+
+	// PIDThrottlePosG will request throttle position G. Use with DecodeThrottlePos
+	PIDThrottlePosG byte = 0x8d
+
+	// PIDFrictionTorque will request the engine friction percent torque. Use with DecodeTorquePercent
+	PIDFrictionTorque byte = 0x8e
+
+	// PIDPMSensorBanks will request particulate matter sensor output for bank 1 and 2. Response is not decoded
+	PIDPMSensorBanks byte = 0x8f
+
+	// PIDWWHOBDSystemInfo will request the WWH-OBD vehicle OBD system information. Response is bit encoded
+	PIDWWHOBDSystemInfo byte = 0x90
+
+	// PIDECUOBDSystemInfo will request the WWH-OBD ECU OBD system information. Response is bit encoded
+	PIDECUOBDSystemInfo byte = 0x91
+
+	// PIDFuelSystemControl will request the fuel system control status. Response is bit encoded
+	PIDFuelSystemControl byte = 0x92
+
+	// PIDWWHOBDCounters will request the WWH-OBD vehicle OBD counters. Response is not decoded
+	PIDWWHOBDCounters byte = 0x93
+
+	// PIDNOxWarning will request the NOx warning and inducement system status. Response is not decoded
+	PIDNOxWarning byte = 0x94
+
+	// PIDEGTBank1Ext will request the exhaust gas temperature of bank 1, sensors 5 to 8. Use with DecodeEGT
+	PIDEGTBank1Ext byte = 0x98
+
+	// PIDEGTBank2Ext will request the exhaust gas temperature of bank 2, sensors 5 to 8. Use with DecodeEGT
+	PIDEGTBank2Ext byte = 0x99
+
+	// PIDHybridSystemData will request hybrid/EV system data, battery and voltage. Response is not decoded
+	PIDHybridSystemData byte = 0x9a
+
+	// PIDDEFSensor will request the diesel exhaust fluid sensor data. Response is not decoded
+	PIDDEFSensor byte = 0x9b
+
+	// PIDO2SensorData will request oxygen sensor data. Response is not decoded
+	PIDO2SensorData byte = 0x9c
+
+	// PIDFuelRate will request the engine and vehicle fuel rate. Use with DecodeFuelRate
+	PIDFuelRate byte = 0x9d
+
+	// PIDExhaustFlowRate will request the engine exhaust flow rate. Use with DecodeExhaustFlowRate
+	PIDExhaustFlowRate byte = 0x9e
+
+	// PIDFuelSystemUse will request the fuel system percentage use. Response is not decoded
+	PIDFuelSystemUse byte = 0x9f
+
+	// PIDSupport6 will return supported PIDs from 0xA1 to 0xC0
+	PIDSupport6 byte = 0xa0
+
+	// PIDNOxSensorCorrected will request the corrected NOx sensor data. Response is not decoded
+	PIDNOxSensorCorrected byte = 0xa1
+
+	// PIDCylinderFuelRate will request the cylinder fuel rate. Use with DecodeCylinderFuelRate
+	PIDCylinderFuelRate byte = 0xa2
+
+	// PIDEvapVaporPressureExt will request evaporative system vapor pressure data. Response is not decoded
+	PIDEvapVaporPressureExt byte = 0xa3
+
+	// PIDTransmissionGear will request the transmission actual gear and gear ratio. Use with DecodeTransmissionGear
+	PIDTransmissionGear byte = 0xa4
+
+	// PIDDEFDosing will request the commanded diesel exhaust fluid dosing. Use with DecodeDEFDosing
+	PIDDEFDosing byte = 0xa5
+
+	// PIDOdometer will request the odometer reading. Use with DecodeOdometer
+	PIDOdometer byte = 0xa6
+
+	// PIDNOxSensor34 will request the NOx concentration of sensors 3 and 4. Response is not decoded
+	PIDNOxSensor34 byte = 0xa7
+
+	// PIDNOxSensorCorrected34 will request the corrected NOx concentration of sensors 3 and 4. Response is not decoded
+	PIDNOxSensorCorrected34 byte = 0xa8
+
+	// PIDABSDisable will request the ABS disable switch state. Response is bit encoded
+	PIDABSDisable byte = 0xa9
+
+	// PIDSupport7 will return supported PIDs from 0xC1 to 0xE0
+	PIDSupport7 byte = 0xc0
+
+	// PIDDriveCondition will request drive condition data. Response is not standardized and is not decoded
+	PIDDriveCondition byte = 0xc3
+
+	// PIDEngineIdleStopRequest will request the engine idle and stop requests. Response is not standardized and is not decoded
+	PIDEngineIdleStopRequest byte = 0xc4
+
+	// PIDSupport8 will return supported PIDs from 0xE1 to 0xFF
+	PIDSupport8 byte = 0xe0
 )
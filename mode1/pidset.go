@@ -0,0 +1,62 @@
+package mode1
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PIDSet is a set of PIDs. The zero value is an empty set
+type PIDSet [4]uint64
+
+// Add will add pid to the set
+func (s *PIDSet) Add(pid byte) {
+	s[pid/64] |= 1 << (pid % 64)
+}
+
+// Has will return true if pid is in the set
+func (s PIDSet) Has(pid byte) bool {
+	return s[pid/64]&(1<<(pid%64)) != 0
+}
+
+// AddSupported will add the PIDs marked supported in res, the response to the support PID base (e.g. PIDSupport2). res must be 4 bytes
+func (s *PIDSet) AddSupported(base byte, res []byte) {
+	for i := 1; i <= 32 && int(base)+i <= 0xff; i++ {
+		if IsSupported(res, byte(i)) {
+			s.Add(base + byte(i))
+		}
+	}
+}
+
+// Union will return a set containing the PIDs of both sets
+func (s PIDSet) Union(o PIDSet) PIDSet {
+	for i := range s {
+		s[i] |= o[i]
+	}
+	return s
+}
+
+// Len will return the number of PIDs in the set
+func (s PIDSet) Len() int {
+	return len(s.PIDs())
+}
+
+// PIDs will return the PIDs in the set, in order
+func (s PIDSet) PIDs() []byte {
+	var pids []byte
+	for i := 0; i <= 0xff; i++ {
+		if s.Has(byte(i)) {
+			pids = append(pids, byte(i))
+		}
+	}
+	return pids
+}
+
+// String will return the PIDs in the set as hex values (e.g. "[01 04 05 0C]")
+func (s PIDSet) String() string {
+	pids := s.PIDs()
+	strs := make([]string, len(pids))
+	for i, pid := range pids {
+		strs[i] = fmt.Sprintf("%02X", pid)
+	}
+	return "[" + strings.Join(strs, " ") + "]"
+}
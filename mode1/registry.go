@@ -0,0 +1,1735 @@
+package mode1
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/mastercactapus/obd2/units"
+)
+
+// Errors returned by Decode
+var (
+	ErrUnknownPID    = errors.New("unknown PID")
+	ErrNotDecoded    = errors.New("PID response is not decoded")
+	ErrShortResponse = errors.New("response too short")
+)
+
+// PIDInfo describes a mode 1 PID, so that tooling can handle any PID without knowing about it ahead of time
+type PIDInfo struct {
+	PID byte
+
+	// Name is a human readable name for the PID
+	Name string
+
+	// ShortName is a short identifier, suitable for column headers or command line arguments
+	ShortName string
+
+	// Description is a longer description of what the PID reports
+	Description string
+
+	// Length is the number of data bytes in the response (not including the mode and PID). It is 0 if the length is not known
+	Length int
+
+	// Unit is the unit of the decoded value(s), if any. Percentages are decoded as a fraction (1 is 100%)
+	Unit units.Unit
+
+	// Min and Max are the range of the decoded value(s) in Unit. Both are 0 if the value is not a number
+	Min, Max float64
+
+	// Decode will decode the response data (not including the mode and PID) into a typed value (e.g. float64, MonitorStatus,
+	// FuelPressureControl). res must be Length bytes. It is nil if the PID is not decoded
+	Decode func(res []byte) interface{}
+}
+
+// Quantity will return v, a decoded number, as a Quantity in the unit of the PID
+func (info PIDInfo) Quantity(v float64) units.Quantity {
+	return units.Of(v, info.Unit)
+}
+
+var pidIndex = make(map[byte]int, len(pidInfos))
+
+func init() {
+	sort.Slice(pidInfos, func(i, j int) bool { return pidInfos[i].PID < pidInfos[j].PID })
+	for i, info := range pidInfos {
+		pidIndex[info.PID] = i
+	}
+}
+
+// Lookup will return the PIDInfo for pid. ok will be false if the PID is not known
+func Lookup(pid byte) (info PIDInfo, ok bool) {
+	i, ok := pidIndex[pid]
+	if !ok {
+		return PIDInfo{}, false
+	}
+	return pidInfos[i], true
+}
+
+// PIDInfos will return the PIDInfo for all known PIDs, ordered by PID
+func PIDInfos() []PIDInfo {
+	infos := make([]PIDInfo, len(pidInfos))
+	copy(infos, pidInfos)
+	return infos
+}
+
+// Decode will decode the response data for pid (not including the mode and PID) using the registered decoder. A *LengthError
+// is returned if res is too short, and ErrNotAvailable if the ECU reports none of the sensors in the response as supported
+func Decode(pid byte, res []byte) (interface{}, error) {
+	info, ok := Lookup(pid)
+	if !ok {
+		return nil, ErrUnknownPID
+	}
+	if info.Decode == nil {
+		return nil, ErrNotDecoded
+	}
+	if err := checkLength(res, info.Length); err != nil {
+		return nil, err
+	}
+	v := info.Decode(res)
+	if s, ok := v.(sensors); ok {
+		return v, sensorsAvailable(s)
+	}
+	return v, nil
+}
+
+func supportedPIDs(base byte) func(res []byte) interface{} {
+	return func(res []byte) interface{} {
+		var s PIDSet
+		s.AddSupported(base, res)
+		return s
+	}
+}
+
+var pidInfos = []PIDInfo{
+	{
+		PID:         PIDSupport1,
+		Name:        "PIDs supported [01-20]",
+		ShortName:   "PIDS_A",
+		Description: "Supported PIDs from 0x01 to 0x20",
+		Length:      4,
+		Decode:      supportedPIDs(PIDSupport1),
+	},
+	{
+		PID:         PIDMonitorStatus,
+		Name:        "Monitor status since DTCs cleared",
+		ShortName:   "STATUS",
+		Description: "MIL status, DTC count and readiness monitors since DTCs were cleared",
+		Length:      4,
+		Decode:      func(r []byte) interface{} { return DecodeMonitorStatus(r) },
+	},
+	{
+		PID:         PIDFreeze,
+		Name:        "Freeze DTC",
+		ShortName:   "FREEZE_DTC",
+		Description: "DTC that caused the freeze frame to be stored",
+		Length:      2,
+	},
+	{
+		PID:         PIDFuelSystemStatus,
+		Name:        "Fuel system status",
+		ShortName:   "FUEL_STATUS",
+		Description: "Loop status of fuel systems 1 and 2",
+		Length:      2,
+		Decode:      func(r []byte) interface{} { return [2]FuelSystemStatus{FuelSystemStatus(r[0]), FuelSystemStatus(r[1])} },
+	},
+	{
+		PID:         PIDEngineLoad,
+		Name:        "Calculated engine load",
+		ShortName:   "ENGINE_LOAD",
+		Description: "Calculated engine load",
+		Length:      1,
+		Unit:        "%",
+		Min:         0,
+		Max:         1,
+		Decode:      func(r []byte) interface{} { return DecodeEngineLoad(r[0]) },
+	},
+	{
+		PID:         PIDECT,
+		Name:        "Engine coolant temperature",
+		ShortName:   "COOLANT_TEMP",
+		Description: "Engine coolant temperature",
+		Length:      1,
+		Unit:        "°C",
+		Min:         -40,
+		Max:         215,
+		Decode:      func(r []byte) interface{} { return DecodeECT(r[0]) },
+	},
+	{
+		PID:         PIDSTFTBank1,
+		Name:        "Short term fuel trim - bank 1",
+		ShortName:   "SHORT_FUEL_TRIM_1",
+		Description: "Short term fuel trim (bank 1)",
+		Length:      1,
+		Unit:        "%",
+		Min:         -1,
+		Max:         0.9921875,
+		Decode:      func(r []byte) interface{} { return DecodeFuelTrim(r[0]) },
+	},
+	{
+		PID:         PIDLTFTBank1,
+		Name:        "Long term fuel trim - bank 1",
+		ShortName:   "LONG_FUEL_TRIM_1",
+		Description: "Long term fuel trim (bank 1)",
+		Length:      1,
+		Unit:        "%",
+		Min:         -1,
+		Max:         0.9921875,
+		Decode:      func(r []byte) interface{} { return DecodeFuelTrim(r[0]) },
+	},
+	{
+		PID:         PIDSTFTBank2,
+		Name:        "Short term fuel trim - bank 2",
+		ShortName:   "SHORT_FUEL_TRIM_2",
+		Description: "Short term fuel trim (bank 2)",
+		Length:      1,
+		Unit:        "%",
+		Min:         -1,
+		Max:         0.9921875,
+		Decode:      func(r []byte) interface{} { return DecodeFuelTrim(r[0]) },
+	},
+	{
+		PID:         PIDLTFTBank2,
+		Name:        "Long term fuel trim - bank 2",
+		ShortName:   "LONG_FUEL_TRIM_2",
+		Description: "Long term fuel trim (bank 2)",
+		Length:      1,
+		Unit:        "%",
+		Min:         -1,
+		Max:         0.9921875,
+		Decode:      func(r []byte) interface{} { return DecodeFuelTrim(r[0]) },
+	},
+	{
+		PID:         PIDFuelPressure,
+		Name:        "Fuel pressure",
+		ShortName:   "FUEL_PRESSURE",
+		Description: "Fuel pressure (gauge pressure)",
+		Length:      1,
+		Unit:        "kPa",
+		Min:         0,
+		Max:         765,
+		Decode:      func(r []byte) interface{} { return DecodeFuelPressure(r[0]) },
+	},
+	{
+		PID:         PIDIntakeMAP,
+		Name:        "Intake manifold absolute pressure",
+		ShortName:   "INTAKE_PRESSURE",
+		Description: "Intake manifold absolute pressure",
+		Length:      1,
+		Unit:        "kPa",
+		Min:         0,
+		Max:         255,
+		Decode:      func(r []byte) interface{} { return int(r[0]) },
+	},
+	{
+		PID:         PIDEngineRPM,
+		Name:        "Engine speed",
+		ShortName:   "RPM",
+		Description: "Engine speed",
+		Length:      2,
+		Unit:        "rpm",
+		Min:         0,
+		Max:         16383.75,
+		Decode:      func(r []byte) interface{} { return DecodeEngineRPM(r) },
+	},
+	{
+		PID:         PIDVehicleSpeed,
+		Name:        "Vehicle speed",
+		ShortName:   "SPEED",
+		Description: "Vehicle speed",
+		Length:      1,
+		Unit:        "km/h",
+		Min:         0,
+		Max:         255,
+		Decode:      func(r []byte) interface{} { return int(r[0]) },
+	},
+	{
+		PID:         PIDTimingAdvance,
+		Name:        "Timing advance",
+		ShortName:   "TIMING_ADVANCE",
+		Description: "Timing advance before TDC",
+		Length:      1,
+		Unit:        "°",
+		Min:         -64,
+		Max:         63.5,
+		Decode:      func(r []byte) interface{} { return DecodeTimingAdvance(r[0]) },
+	},
+	{
+		PID:         PIDIAT,
+		Name:        "Intake air temperature",
+		ShortName:   "INTAKE_TEMP",
+		Description: "Intake air temperature",
+		Length:      1,
+		Unit:        "°C",
+		Min:         -40,
+		Max:         215,
+		Decode:      func(r []byte) interface{} { return DecodeIAT(r[0]) },
+	},
+	{
+		PID:         PIDMAFRate,
+		Name:        "Mass air flow rate",
+		ShortName:   "MAF",
+		Description: "Mass air flow sensor air flow rate",
+		Length:      2,
+		Unit:        "g/s",
+		Min:         0,
+		Max:         655.35,
+		Decode:      func(r []byte) interface{} { return DecodeMAFRate(r) },
+	},
+	{
+		PID:         PIDThrottlePos,
+		Name:        "Throttle position",
+		ShortName:   "THROTTLE_POS",
+		Description: "Throttle position",
+		Length:      1,
+		Unit:        "%",
+		Min:         0,
+		Max:         1,
+		Decode:      func(r []byte) interface{} { return DecodeThrottlePos(r[0]) },
+	},
+	{
+		PID:         PIDComAirStatus,
+		Name:        "Commanded secondary air status",
+		ShortName:   "AIR_STATUS",
+		Description: "Commanded secondary air status",
+		Length:      1,
+		Decode:      func(r []byte) interface{} { return ComAirStatus(r[0]) },
+	},
+	{
+		PID:         PIDO2Present,
+		Name:        "Oxygen sensors present",
+		ShortName:   "O2_SENSORS",
+		Description: "Oxygen sensors present in banks 1 and 2",
+		Length:      1,
+		Decode:      func(r []byte) interface{} { return DecodeO2Present(r[0]) },
+	},
+	{
+		PID:         PIDO2STFT1,
+		Name:        "Oxygen sensor 1",
+		ShortName:   "O2_S1",
+		Description: "Oxygen sensor 1 voltage and short term fuel trim",
+		Length:      2,
+		Decode:      func(r []byte) interface{} { return DecodeO2STFT(r) },
+	},
+	{
+		PID:         PIDO2STFT2,
+		Name:        "Oxygen sensor 2",
+		ShortName:   "O2_S2",
+		Description: "Oxygen sensor 2 voltage and short term fuel trim",
+		Length:      2,
+		Decode:      func(r []byte) interface{} { return DecodeO2STFT(r) },
+	},
+	{
+		PID:         PIDO2STFT3,
+		Name:        "Oxygen sensor 3",
+		ShortName:   "O2_S3",
+		Description: "Oxygen sensor 3 voltage and short term fuel trim",
+		Length:      2,
+		Decode:      func(r []byte) interface{} { return DecodeO2STFT(r) },
+	},
+	{
+		PID:         PIDO2STFT4,
+		Name:        "Oxygen sensor 4",
+		ShortName:   "O2_S4",
+		Description: "Oxygen sensor 4 voltage and short term fuel trim",
+		Length:      2,
+		Decode:      func(r []byte) interface{} { return DecodeO2STFT(r) },
+	},
+	{
+		PID:         PIDO2STFT5,
+		Name:        "Oxygen sensor 5",
+		ShortName:   "O2_S5",
+		Description: "Oxygen sensor 5 voltage and short term fuel trim",
+		Length:      2,
+		Decode:      func(r []byte) interface{} { return DecodeO2STFT(r) },
+	},
+	{
+		PID:         PIDO2STFT6,
+		Name:        "Oxygen sensor 6",
+		ShortName:   "O2_S6",
+		Description: "Oxygen sensor 6 voltage and short term fuel trim",
+		Length:      2,
+		Decode:      func(r []byte) interface{} { return DecodeO2STFT(r) },
+	},
+	{
+		PID:         PIDO2STFT7,
+		Name:        "Oxygen sensor 7",
+		ShortName:   "O2_S7",
+		Description: "Oxygen sensor 7 voltage and short term fuel trim",
+		Length:      2,
+		Decode:      func(r []byte) interface{} { return DecodeO2STFT(r) },
+	},
+	{
+		PID:         PIDO2STFT8,
+		Name:        "Oxygen sensor 8",
+		ShortName:   "O2_S8",
+		Description: "Oxygen sensor 8 voltage and short term fuel trim",
+		Length:      2,
+		Decode:      func(r []byte) interface{} { return DecodeO2STFT(r) },
+	},
+	{
+		PID:         PIDOBDStandard,
+		Name:        "OBD standards",
+		ShortName:   "OBD_COMPLIANCE",
+		Description: "OBD standards this vehicle conforms to",
+		Length:      1,
+		Decode:      func(r []byte) interface{} { return OBDStandard(r[0]) },
+	},
+	{
+		PID:         PIDO2PresentExt,
+		Name:        "Oxygen sensors present (4 banks)",
+		ShortName:   "O2_SENSORS_ALT",
+		Description: "Oxygen sensors present in banks 1 to 4",
+		Length:      1,
+	},
+	{
+		PID:         PIDAuxInput,
+		Name:        "Auxiliary input status",
+		ShortName:   "AUX_INPUT_STATUS",
+		Description: "Auxiliary input status",
+		Length:      1,
+		Decode:      func(r []byte) interface{} { return DecodeAuxInput(r[0]) },
+	},
+	{
+		PID:         PIDRunTime,
+		Name:        "Run time since engine start",
+		ShortName:   "RUN_TIME",
+		Description: "Run time since engine start",
+		Length:      2,
+		Unit:        "s",
+		Min:         0,
+		Max:         65535,
+		Decode:      func(r []byte) interface{} { return DecodeRunTime(r) },
+	},
+	{
+		PID:         PIDSupport2,
+		Name:        "PIDs supported [21-40]",
+		ShortName:   "PIDS_B",
+		Description: "Supported PIDs from 0x21 to 0x40",
+		Length:      4,
+		Decode:      supportedPIDs(PIDSupport2),
+	},
+	{
+		PID:         PIDDistanceMIL,
+		Name:        "Distance traveled with MIL on",
+		ShortName:   "DISTANCE_W_MIL",
+		Description: "Distance traveled with the malfunction indicator lamp on",
+		Length:      2,
+		Unit:        "km",
+		Min:         0,
+		Max:         65535,
+		Decode:      func(r []byte) interface{} { return DecodeDistance(r) },
+	},
+	{
+		PID:         PIDFuelRailPressureRelative,
+		Name:        "Fuel rail pressure (relative)",
+		ShortName:   "FUEL_RAIL_PRESSURE_VAC",
+		Description: "Fuel rail pressure, relative to manifold vacuum",
+		Length:      2,
+		Unit:        "kPa",
+		Min:         0,
+		Max:         5177.265,
+		Decode:      func(r []byte) interface{} { return DecodeFuelRailPressureRelative(r) },
+	},
+	{
+		PID:         PIDFuelRailPressure,
+		Name:        "Fuel rail gauge pressure",
+		ShortName:   "FUEL_RAIL_PRESSURE_DIRECT",
+		Description: "Fuel rail gauge pressure (diesel, or gasoline direct injection)",
+		Length:      2,
+		Unit:        "kPa",
+		Min:         0,
+		Max:         655350,
+		Decode:      func(r []byte) interface{} { return DecodeFuelRailPressure(r) },
+	},
+	{
+		PID:         PIDO2WRVoltage1,
+		Name:        "Oxygen sensor 1 (wide range voltage)",
+		ShortName:   "O2_S1_WR_VOLTAGE",
+		Description: "Wide-band oxygen sensor 1 equivalence ratio and voltage",
+		Length:      4,
+		Decode:      func(r []byte) interface{} { return DecodeO2WRVoltage(r) },
+	},
+	{
+		PID:         PIDO2WRVoltage2,
+		Name:        "Oxygen sensor 2 (wide range voltage)",
+		ShortName:   "O2_S2_WR_VOLTAGE",
+		Description: "Wide-band oxygen sensor 2 equivalence ratio and voltage",
+		Length:      4,
+		Decode:      func(r []byte) interface{} { return DecodeO2WRVoltage(r) },
+	},
+	{
+		PID:         PIDO2WRVoltage3,
+		Name:        "Oxygen sensor 3 (wide range voltage)",
+		ShortName:   "O2_S3_WR_VOLTAGE",
+		Description: "Wide-band oxygen sensor 3 equivalence ratio and voltage",
+		Length:      4,
+		Decode:      func(r []byte) interface{} { return DecodeO2WRVoltage(r) },
+	},
+	{
+		PID:         PIDO2WRVoltage4,
+		Name:        "Oxygen sensor 4 (wide range voltage)",
+		ShortName:   "O2_S4_WR_VOLTAGE",
+		Description: "Wide-band oxygen sensor 4 equivalence ratio and voltage",
+		Length:      4,
+		Decode:      func(r []byte) interface{} { return DecodeO2WRVoltage(r) },
+	},
+	{
+		PID:         PIDO2WRVoltage5,
+		Name:        "Oxygen sensor 5 (wide range voltage)",
+		ShortName:   "O2_S5_WR_VOLTAGE",
+		Description: "Wide-band oxygen sensor 5 equivalence ratio and voltage",
+		Length:      4,
+		Decode:      func(r []byte) interface{} { return DecodeO2WRVoltage(r) },
+	},
+	{
+		PID:         PIDO2WRVoltage6,
+		Name:        "Oxygen sensor 6 (wide range voltage)",
+		ShortName:   "O2_S6_WR_VOLTAGE",
+		Description: "Wide-band oxygen sensor 6 equivalence ratio and voltage",
+		Length:      4,
+		Decode:      func(r []byte) interface{} { return DecodeO2WRVoltage(r) },
+	},
+	{
+		PID:         PIDO2WRVoltage7,
+		Name:        "Oxygen sensor 7 (wide range voltage)",
+		ShortName:   "O2_S7_WR_VOLTAGE",
+		Description: "Wide-band oxygen sensor 7 equivalence ratio and voltage",
+		Length:      4,
+		Decode:      func(r []byte) interface{} { return DecodeO2WRVoltage(r) },
+	},
+	{
+		PID:         PIDO2WRVoltage8,
+		Name:        "Oxygen sensor 8 (wide range voltage)",
+		ShortName:   "O2_S8_WR_VOLTAGE",
+		Description: "Wide-band oxygen sensor 8 equivalence ratio and voltage",
+		Length:      4,
+		Decode:      func(r []byte) interface{} { return DecodeO2WRVoltage(r) },
+	},
+	{
+		PID:         PIDCommandedEGR,
+		Name:        "Commanded EGR",
+		ShortName:   "COMMANDED_EGR",
+		Description: "Commanded exhaust gas recirculation",
+		Length:      1,
+		Unit:        "%",
+		Min:         0,
+		Max:         1,
+		Decode:      func(r []byte) interface{} { return DecodeCommandedEGR(r[0]) },
+	},
+	{
+		PID:         PIDEGRError,
+		Name:        "EGR error",
+		ShortName:   "EGR_ERROR",
+		Description: "Exhaust gas recirculation error, relative to commanded",
+		Length:      1,
+		Unit:        "%",
+		Min:         -1,
+		Max:         0.9921875,
+		Decode:      func(r []byte) interface{} { return DecodeEGRError(r[0]) },
+	},
+	{
+		PID:         PIDEvapPurge,
+		Name:        "Commanded evaporative purge",
+		ShortName:   "EVAPORATIVE_PURGE",
+		Description: "Commanded evaporative purge",
+		Length:      1,
+		Unit:        "%",
+		Min:         0,
+		Max:         1,
+		Decode:      func(r []byte) interface{} { return DecodeEvapPurge(r[0]) },
+	},
+	{
+		PID:         PIDFuelTankLevel,
+		Name:        "Fuel tank level input",
+		ShortName:   "FUEL_LEVEL",
+		Description: "Fuel tank level input",
+		Length:      1,
+		Unit:        "%",
+		Min:         0,
+		Max:         1,
+		Decode:      func(r []byte) interface{} { return DecodeFuelTankLevel(r[0]) },
+	},
+	{
+		PID:         PIDWarmUps,
+		Name:        "Warm-ups since codes cleared",
+		ShortName:   "WARMUPS_SINCE_DTC_CLEAR",
+		Description: "Number of warm-ups since codes were cleared",
+		Length:      1,
+		Min:         0,
+		Max:         255,
+		Decode:      func(r []byte) interface{} { return DecodeWarmUps(r[0]) },
+	},
+	{
+		PID:         PIDDistanceSinceClear,
+		Name:        "Distance traveled since codes cleared",
+		ShortName:   "DISTANCE_SINCE_DTC_CLEAR",
+		Description: "Distance traveled since codes were cleared",
+		Length:      2,
+		Unit:        "km",
+		Min:         0,
+		Max:         65535,
+		Decode:      func(r []byte) interface{} { return DecodeDistance(r) },
+	},
+	{
+		PID:         PIDEvapVaporPressure,
+		Name:        "Evap system vapor pressure",
+		ShortName:   "EVAP_VAPOR_PRESSURE",
+		Description: "Evaporative system vapor pressure",
+		Length:      2,
+		Unit:        "Pa",
+		Min:         -8192,
+		Max:         8191.75,
+		Decode:      func(r []byte) interface{} { return DecodeEvapVaporPressure(r) },
+	},
+	{
+		PID:         PIDBarometricPressure,
+		Name:        "Absolute barometric pressure",
+		ShortName:   "BAROMETRIC_PRESSURE",
+		Description: "Absolute barometric pressure",
+		Length:      1,
+		Unit:        "kPa",
+		Min:         0,
+		Max:         255,
+		Decode:      func(r []byte) interface{} { return DecodeBarometricPressure(r[0]) },
+	},
+	{
+		PID:         PIDO2WRCurrent1,
+		Name:        "Oxygen sensor 1 (wide range current)",
+		ShortName:   "O2_S1_WR_CURRENT",
+		Description: "Wide-band oxygen sensor 1 equivalence ratio and current",
+		Length:      4,
+		Decode:      func(r []byte) interface{} { return DecodeO2WRCurrent(r) },
+	},
+	{
+		PID:         PIDO2WRCurrent2,
+		Name:        "Oxygen sensor 2 (wide range current)",
+		ShortName:   "O2_S2_WR_CURRENT",
+		Description: "Wide-band oxygen sensor 2 equivalence ratio and current",
+		Length:      4,
+		Decode:      func(r []byte) interface{} { return DecodeO2WRCurrent(r) },
+	},
+	{
+		PID:         PIDO2WRCurrent3,
+		Name:        "Oxygen sensor 3 (wide range current)",
+		ShortName:   "O2_S3_WR_CURRENT",
+		Description: "Wide-band oxygen sensor 3 equivalence ratio and current",
+		Length:      4,
+		Decode:      func(r []byte) interface{} { return DecodeO2WRCurrent(r) },
+	},
+	{
+		PID:         PIDO2WRCurrent4,
+		Name:        "Oxygen sensor 4 (wide range current)",
+		ShortName:   "O2_S4_WR_CURRENT",
+		Description: "Wide-band oxygen sensor 4 equivalence ratio and current",
+		Length:      4,
+		Decode:      func(r []byte) interface{} { return DecodeO2WRCurrent(r) },
+	},
+	{
+		PID:         PIDO2WRCurrent5,
+		Name:        "Oxygen sensor 5 (wide range current)",
+		ShortName:   "O2_S5_WR_CURRENT",
+		Description: "Wide-band oxygen sensor 5 equivalence ratio and current",
+		Length:      4,
+		Decode:      func(r []byte) interface{} { return DecodeO2WRCurrent(r) },
+	},
+	{
+		PID:         PIDO2WRCurrent6,
+		Name:        "Oxygen sensor 6 (wide range current)",
+		ShortName:   "O2_S6_WR_CURRENT",
+		Description: "Wide-band oxygen sensor 6 equivalence ratio and current",
+		Length:      4,
+		Decode:      func(r []byte) interface{} { return DecodeO2WRCurrent(r) },
+	},
+	{
+		PID:         PIDO2WRCurrent7,
+		Name:        "Oxygen sensor 7 (wide range current)",
+		ShortName:   "O2_S7_WR_CURRENT",
+		Description: "Wide-band oxygen sensor 7 equivalence ratio and current",
+		Length:      4,
+		Decode:      func(r []byte) interface{} { return DecodeO2WRCurrent(r) },
+	},
+	{
+		PID:         PIDO2WRCurrent8,
+		Name:        "Oxygen sensor 8 (wide range current)",
+		ShortName:   "O2_S8_WR_CURRENT",
+		Description: "Wide-band oxygen sensor 8 equivalence ratio and current",
+		Length:      4,
+		Decode:      func(r []byte) interface{} { return DecodeO2WRCurrent(r) },
+	},
+	{
+		PID:         PIDCatalystTempB1S1,
+		Name:        "Catalyst temperature: bank 1, sensor 1",
+		ShortName:   "CATALYST_TEMP_B1S1",
+		Description: "Catalyst temperature of bank 1, sensor 1",
+		Length:      2,
+		Unit:        "°C",
+		Min:         -40,
+		Max:         6513.5,
+		Decode:      func(r []byte) interface{} { return DecodeCatalystTemp(r) },
+	},
+	{
+		PID:         PIDCatalystTempB2S1,
+		Name:        "Catalyst temperature: bank 2, sensor 1",
+		ShortName:   "CATALYST_TEMP_B2S1",
+		Description: "Catalyst temperature of bank 2, sensor 1",
+		Length:      2,
+		Unit:        "°C",
+		Min:         -40,
+		Max:         6513.5,
+		Decode:      func(r []byte) interface{} { return DecodeCatalystTemp(r) },
+	},
+	{
+		PID:         PIDCatalystTempB1S2,
+		Name:        "Catalyst temperature: bank 1, sensor 2",
+		ShortName:   "CATALYST_TEMP_B1S2",
+		Description: "Catalyst temperature of bank 1, sensor 2",
+		Length:      2,
+		Unit:        "°C",
+		Min:         -40,
+		Max:         6513.5,
+		Decode:      func(r []byte) interface{} { return DecodeCatalystTemp(r) },
+	},
+	{
+		PID:         PIDCatalystTempB2S2,
+		Name:        "Catalyst temperature: bank 2, sensor 2",
+		ShortName:   "CATALYST_TEMP_B2S2",
+		Description: "Catalyst temperature of bank 2, sensor 2",
+		Length:      2,
+		Unit:        "°C",
+		Min:         -40,
+		Max:         6513.5,
+		Decode:      func(r []byte) interface{} { return DecodeCatalystTemp(r) },
+	},
+	{
+		PID:         PIDSupport3,
+		Name:        "PIDs supported [41-60]",
+		ShortName:   "PIDS_C",
+		Description: "Supported PIDs from 0x41 to 0x60",
+		Length:      4,
+		Decode:      supportedPIDs(PIDSupport3),
+	},
+	{
+		PID:         PIDMonitorStatusDriveCycle,
+		Name:        "Monitor status this drive cycle",
+		ShortName:   "STATUS_DRIVE_CYCLE",
+		Description: "Readiness monitors this drive cycle",
+		Length:      4,
+		Decode:      func(r []byte) interface{} { return DecodeMonitorStatus(r) },
+	},
+	{
+		PID:         PIDControlModuleVoltage,
+		Name:        "Control module voltage",
+		ShortName:   "CONTROL_MODULE_VOLTAGE",
+		Description: "Control module voltage",
+		Length:      2,
+		Unit:        "V",
+		Min:         0,
+		Max:         65.535,
+		Decode:      func(r []byte) interface{} { return DecodeControlModuleVoltage(r) },
+	},
+	{
+		PID:         PIDAbsoluteLoad,
+		Name:        "Absolute load value",
+		ShortName:   "ABSOLUTE_LOAD",
+		Description: "Absolute load value",
+		Length:      2,
+		Unit:        "%",
+		Min:         0,
+		Max:         257,
+		Decode:      func(r []byte) interface{} { return DecodeAbsoluteLoad(r) },
+	},
+	{
+		PID:         PIDCommandedEquivRatio,
+		Name:        "Commanded equivalence ratio",
+		ShortName:   "COMMANDED_EQUIV_RATIO",
+		Description: "Commanded fuel-air equivalence ratio",
+		Length:      2,
+		Unit:        "ratio",
+		Min:         0,
+		Max:         2,
+		Decode:      func(r []byte) interface{} { return DecodeCommandedEquivRatio(r) },
+	},
+	{
+		PID:         PIDRelativeThrottlePos,
+		Name:        "Relative throttle position",
+		ShortName:   "RELATIVE_THROTTLE_POS",
+		Description: "Relative throttle position",
+		Length:      1,
+		Unit:        "%",
+		Min:         0,
+		Max:         1,
+		Decode:      func(r []byte) interface{} { return DecodeThrottlePos(r[0]) },
+	},
+	{
+		PID:         PIDAmbientAirTemp,
+		Name:        "Ambient air temperature",
+		ShortName:   "AMBIANT_AIR_TEMP",
+		Description: "Ambient air temperature",
+		Length:      1,
+		Unit:        "°C",
+		Min:         -40,
+		Max:         215,
+		Decode:      func(r []byte) interface{} { return DecodeAmbientAirTemp(r[0]) },
+	},
+	{
+		PID:         PIDAbsoluteThrottlePosB,
+		Name:        "Absolute throttle position B",
+		ShortName:   "THROTTLE_POS_B",
+		Description: "Absolute throttle position B",
+		Length:      1,
+		Unit:        "%",
+		Min:         0,
+		Max:         1,
+		Decode:      func(r []byte) interface{} { return DecodeThrottlePos(r[0]) },
+	},
+	{
+		PID:         PIDAbsoluteThrottlePosC,
+		Name:        "Absolute throttle position C",
+		ShortName:   "THROTTLE_POS_C",
+		Description: "Absolute throttle position C",
+		Length:      1,
+		Unit:        "%",
+		Min:         0,
+		Max:         1,
+		Decode:      func(r []byte) interface{} { return DecodeThrottlePos(r[0]) },
+	},
+	{
+		PID:         PIDAccelPedalPosD,
+		Name:        "Accelerator pedal position D",
+		ShortName:   "ACCELERATOR_POS_D",
+		Description: "Accelerator pedal position D",
+		Length:      1,
+		Unit:        "%",
+		Min:         0,
+		Max:         1,
+		Decode:      func(r []byte) interface{} { return DecodePedalPos(r[0]) },
+	},
+	{
+		PID:         PIDAccelPedalPosE,
+		Name:        "Accelerator pedal position E",
+		ShortName:   "ACCELERATOR_POS_E",
+		Description: "Accelerator pedal position E",
+		Length:      1,
+		Unit:        "%",
+		Min:         0,
+		Max:         1,
+		Decode:      func(r []byte) interface{} { return DecodePedalPos(r[0]) },
+	},
+	{
+		PID:         PIDAccelPedalPosF,
+		Name:        "Accelerator pedal position F",
+		ShortName:   "ACCELERATOR_POS_F",
+		Description: "Accelerator pedal position F",
+		Length:      1,
+		Unit:        "%",
+		Min:         0,
+		Max:         1,
+		Decode:      func(r []byte) interface{} { return DecodePedalPos(r[0]) },
+	},
+	{
+		PID:         PIDCommandedThrottleActuator,
+		Name:        "Commanded throttle actuator",
+		ShortName:   "THROTTLE_ACTUATOR",
+		Description: "Commanded throttle actuator",
+		Length:      1,
+		Unit:        "%",
+		Min:         0,
+		Max:         1,
+		Decode:      func(r []byte) interface{} { return DecodeCommandedThrottleActuator(r[0]) },
+	},
+	{
+		PID:         PIDTimeMIL,
+		Name:        "Time run with MIL on",
+		ShortName:   "RUN_TIME_MIL",
+		Description: "Time run with the malfunction indicator lamp on",
+		Length:      2,
+		Unit:        "min",
+		Min:         0,
+		Max:         65535,
+		Decode:      func(r []byte) interface{} { return DecodeMinutes(r) },
+	},
+	{
+		PID:         PIDTimeSinceClear,
+		Name:        "Time since codes cleared",
+		ShortName:   "TIME_SINCE_DTC_CLEARED",
+		Description: "Time since trouble codes were cleared",
+		Length:      2,
+		Unit:        "min",
+		Min:         0,
+		Max:         65535,
+		Decode:      func(r []byte) interface{} { return DecodeMinutes(r) },
+	},
+	{
+		PID:         PIDMaxValues,
+		Name:        "Maximum values",
+		ShortName:   "MAX_VALUES",
+		Description: "Maximum values for equivalence ratio, oxygen sensor voltage and current, and intake MAP",
+		Length:      4,
+		Decode:      func(r []byte) interface{} { return DecodeMaxValues(r) },
+	},
+	{
+		PID:         PIDMaxMAFRate,
+		Name:        "Maximum MAF rate",
+		ShortName:   "MAX_MAF",
+		Description: "Maximum value for the mass air flow rate",
+		Length:      4,
+		Unit:        "g/s",
+		Min:         0,
+		Max:         2550,
+		Decode:      func(r []byte) interface{} { return DecodeMaxMAFRate(r) },
+	},
+	{
+		PID:         PIDFuelType,
+		Name:        "Fuel type",
+		ShortName:   "FUEL_TYPE",
+		Description: "Fuel type",
+		Length:      1,
+		Decode:      func(r []byte) interface{} { return FuelType(r[0]) },
+	},
+	{
+		PID:         PIDEthanolPercent,
+		Name:        "Ethanol fuel percentage",
+		ShortName:   "ETHANOL_PERCENT",
+		Description: "Ethanol fuel percentage",
+		Length:      1,
+		Unit:        "%",
+		Min:         0,
+		Max:         1,
+		Decode:      func(r []byte) interface{} { return DecodeEthanolPercent(r[0]) },
+	},
+	{
+		PID:         PIDEvapPressureAbsolute,
+		Name:        "Absolute evap system vapor pressure",
+		ShortName:   "EVAP_VAPOR_PRESSURE_ABS",
+		Description: "Absolute evaporative system vapor pressure",
+		Length:      2,
+		Unit:        "kPa",
+		Min:         0,
+		Max:         327.675,
+		Decode:      func(r []byte) interface{} { return DecodeEvapPressureAbsolute(r) },
+	},
+	{
+		PID:         PIDEvapVaporPressureWide,
+		Name:        "Evap system vapor pressure (wide)",
+		ShortName:   "EVAP_VAPOR_PRESSURE_ALT",
+		Description: "Evaporative system vapor pressure",
+		Length:      2,
+		Unit:        "Pa",
+		Min:         -32768,
+		Max:         32767,
+		Decode:      func(r []byte) interface{} { return DecodeEvapVaporPressureWide(r) },
+	},
+	{
+		PID:         PIDSecondaryO2STTBank13,
+		Name:        "Short term secondary O2 trim - banks 1 and 3",
+		ShortName:   "SHORT_O2_TRIM_B1",
+		Description: "Short term secondary oxygen sensor trim for banks 1 and 3",
+		Length:      2,
+		Unit:        "%",
+		Min:         -1,
+		Max:         0.9921875,
+		Decode:      func(r []byte) interface{} { return [2]float64{DecodeFuelTrim(r[0]), DecodeFuelTrim(r[1])} },
+	},
+	{
+		PID:         PIDSecondaryO2LTTBank13,
+		Name:        "Long term secondary O2 trim - banks 1 and 3",
+		ShortName:   "LONG_O2_TRIM_B1",
+		Description: "Long term secondary oxygen sensor trim for banks 1 and 3",
+		Length:      2,
+		Unit:        "%",
+		Min:         -1,
+		Max:         0.9921875,
+		Decode:      func(r []byte) interface{} { return [2]float64{DecodeFuelTrim(r[0]), DecodeFuelTrim(r[1])} },
+	},
+	{
+		PID:         PIDSecondaryO2STTBank24,
+		Name:        "Short term secondary O2 trim - banks 2 and 4",
+		ShortName:   "SHORT_O2_TRIM_B2",
+		Description: "Short term secondary oxygen sensor trim for banks 2 and 4",
+		Length:      2,
+		Unit:        "%",
+		Min:         -1,
+		Max:         0.9921875,
+		Decode:      func(r []byte) interface{} { return [2]float64{DecodeFuelTrim(r[0]), DecodeFuelTrim(r[1])} },
+	},
+	{
+		PID:         PIDSecondaryO2LTTBank24,
+		Name:        "Long term secondary O2 trim - banks 2 and 4",
+		ShortName:   "LONG_O2_TRIM_B2",
+		Description: "Long term secondary oxygen sensor trim for banks 2 and 4",
+		Length:      2,
+		Unit:        "%",
+		Min:         -1,
+		Max:         0.9921875,
+		Decode:      func(r []byte) interface{} { return [2]float64{DecodeFuelTrim(r[0]), DecodeFuelTrim(r[1])} },
+	},
+	{
+		PID:         PIDFuelRailPressureAbsolute,
+		Name:        "Fuel rail absolute pressure",
+		ShortName:   "FUEL_RAIL_PRESSURE_ABS",
+		Description: "Fuel rail absolute pressure",
+		Length:      2,
+		Unit:        "kPa",
+		Min:         0,
+		Max:         655350,
+		Decode:      func(r []byte) interface{} { return DecodeFuelRailPressure(r) },
+	},
+	{
+		PID:         PIDRelativePedalPos,
+		Name:        "Relative accelerator pedal position",
+		ShortName:   "RELATIVE_ACCEL_POS",
+		Description: "Relative accelerator pedal position",
+		Length:      1,
+		Unit:        "%",
+		Min:         0,
+		Max:         1,
+		Decode:      func(r []byte) interface{} { return DecodePedalPos(r[0]) },
+	},
+	{
+		PID:         PIDHybridBatteryLife,
+		Name:        "Hybrid battery pack remaining life",
+		ShortName:   "HYBRID_BATTERY_REMAINING",
+		Description: "Hybrid battery pack remaining life",
+		Length:      1,
+		Unit:        "%",
+		Min:         0,
+		Max:         1,
+		Decode:      func(r []byte) interface{} { return DecodeHybridBatteryLife(r[0]) },
+	},
+	{
+		PID:         PIDEngineOilTemp,
+		Name:        "Engine oil temperature",
+		ShortName:   "OIL_TEMP",
+		Description: "Engine oil temperature",
+		Length:      1,
+		Unit:        "°C",
+		Min:         -40,
+		Max:         215,
+		Decode:      func(r []byte) interface{} { return DecodeEngineOilTemp(r[0]) },
+	},
+	{
+		PID:         PIDFuelInjectionTiming,
+		Name:        "Fuel injection timing",
+		ShortName:   "FUEL_INJECT_TIMING",
+		Description: "Fuel injection timing",
+		Length:      2,
+		Unit:        "°",
+		Min:         -210,
+		Max:         301.9921875,
+		Decode:      func(r []byte) interface{} { return DecodeFuelInjectionTiming(r) },
+	},
+	{
+		PID:         PIDEngineFuelRate,
+		Name:        "Engine fuel rate",
+		ShortName:   "FUEL_RATE",
+		Description: "Engine fuel rate",
+		Length:      2,
+		Unit:        "L/h",
+		Min:         0,
+		Max:         3276.75,
+		Decode:      func(r []byte) interface{} { return DecodeEngineFuelRate(r) },
+	},
+	{
+		PID:         PIDEmissionRequirements,
+		Name:        "Emission requirements",
+		ShortName:   "EMISSION_REQ",
+		Description: "Emission requirements the vehicle is designed to",
+		Length:      1,
+	},
+	{
+		PID:         PIDSupport4,
+		Name:        "PIDs supported [61-80]",
+		ShortName:   "PIDS_D",
+		Description: "Supported PIDs from 0x61 to 0x80",
+		Length:      4,
+		Decode:      supportedPIDs(PIDSupport4),
+	},
+	{
+		PID:         PIDDriverDemandTorque,
+		Name:        "Driver's demand engine torque",
+		ShortName:   "DRIVER_DEMAND_TORQUE",
+		Description: "Driver's demand engine percent torque",
+		Length:      1,
+		Unit:        "%",
+		Min:         -1.25,
+		Max:         1.3,
+		Decode:      func(r []byte) interface{} { return DecodeTorquePercent(r[0]) },
+	},
+	{
+		PID:         PIDActualTorque,
+		Name:        "Actual engine torque",
+		ShortName:   "ACTUAL_TORQUE",
+		Description: "Actual engine percent torque",
+		Length:      1,
+		Unit:        "%",
+		Min:         -1.25,
+		Max:         1.3,
+		Decode:      func(r []byte) interface{} { return DecodeTorquePercent(r[0]) },
+	},
+	{
+		PID:         PIDReferenceTorque,
+		Name:        "Engine reference torque",
+		ShortName:   "REFERENCE_TORQUE",
+		Description: "Engine reference torque",
+		Length:      2,
+		Unit:        "Nm",
+		Min:         0,
+		Max:         65535,
+		Decode:      func(r []byte) interface{} { return DecodeReferenceTorque(r) },
+	},
+	{
+		PID:         PIDTorqueData,
+		Name:        "Engine percent torque data",
+		ShortName:   "TORQUE_DATA",
+		Description: "Engine percent torque at idle and engine points 1 to 4",
+		Length:      5,
+		Unit:        "%",
+		Min:         -1.25,
+		Max:         1.3,
+		Decode:      func(r []byte) interface{} { return DecodeTorqueData(r) },
+	},
+	{
+		PID:         PIDAuxIO,
+		Name:        "Auxiliary input/output",
+		ShortName:   "AUX_IO",
+		Description: "Auxiliary input/output status",
+		Length:      2,
+		Decode:      func(r []byte) interface{} { return DecodeAuxIO(r) },
+	},
+	{
+		PID:         PIDMAFSensors,
+		Name:        "Mass air flow sensors",
+		ShortName:   "MAF_SENSORS",
+		Description: "Mass air flow of sensors A and B",
+		Length:      5,
+		Unit:        "g/s",
+		Min:         0,
+		Max:         2047.96875,
+		Decode:      func(r []byte) interface{} { return DecodeMAFSensors(r) },
+	},
+	{
+		PID:         PIDECTSensors,
+		Name:        "Engine coolant temperature sensors",
+		ShortName:   "COOLANT_TEMP_SENSORS",
+		Description: "Engine coolant temperature of sensors 1 and 2",
+		Length:      3,
+		Unit:        "°C",
+		Min:         -40,
+		Max:         215,
+		Decode:      func(r []byte) interface{} { return DecodeECTSensors(r) },
+	},
+	{
+		PID:         PIDIATSensors,
+		Name:        "Intake air temperature sensors",
+		ShortName:   "INTAKE_TEMP_SENSORS",
+		Description: "Intake air temperature of bank 1 and 2, sensors 1 to 3",
+		Length:      7,
+		Unit:        "°C",
+		Min:         -40,
+		Max:         215,
+		Decode:      func(r []byte) interface{} { return DecodeBankTemps(r) },
+	},
+	{
+		PID:         PIDEGRControl,
+		Name:        "EGR control",
+		ShortName:   "EGR_CONTROL",
+		Description: "Commanded and actual EGR duty cycle, and EGR error, for EGR A and B",
+		Length:      7,
+		Unit:        "%",
+		Min:         -1,
+		Max:         1,
+		Decode:      func(r []byte) interface{} { return DecodeEGRControl(r) },
+	},
+	{
+		PID:         PIDIntakeAirFlowControl,
+		Name:        "Diesel intake air flow control",
+		ShortName:   "INTAKE_AIR_FLOW_CONTROL",
+		Description: "Commanded and relative diesel intake air flow control position for A and B",
+		Length:      5,
+		Unit:        "%",
+		Min:         0,
+		Max:         1,
+		Decode:      func(r []byte) interface{} { return DecodePercentSensors(r) },
+	},
+	{
+		PID:         PIDEGRTemp,
+		Name:        "EGR temperature",
+		ShortName:   "EGR_TEMP",
+		Description: "Exhaust gas recirculation temperature of bank 1 and 2, sensors 1 and 2",
+		Length:      5,
+		Unit:        "°C",
+		Min:         -40,
+		Max:         215,
+		Decode:      func(r []byte) interface{} { return DecodeBankTemps(r) },
+	},
+	{
+		PID:         PIDThrottleActuatorControl,
+		Name:        "Throttle actuator control",
+		ShortName:   "THROTTLE_ACTUATOR_CONTROL",
+		Description: "Commanded throttle actuator and relative throttle position for A and B",
+		Length:      5,
+		Unit:        "%",
+		Min:         0,
+		Max:         1,
+		Decode:      func(r []byte) interface{} { return DecodePercentSensors(r) },
+	},
+	{
+		PID:         PIDFuelPressureControl,
+		Name:        "Fuel pressure control system",
+		ShortName:   "FUEL_PRESSURE_CONTROL",
+		Description: "Commanded and actual fuel rail pressure and fuel temperature for fuel systems 1 and 2",
+		Length:      11,
+		Decode:      func(r []byte) interface{} { return DecodeFuelPressureControl(r) },
+	},
+	{
+		PID:         PIDInjectionPressureControl,
+		Name:        "Injection pressure control system",
+		ShortName:   "INJECTION_PRESSURE_CONTROL",
+		Description: "Commanded and actual injection control pressure for A and B",
+		Length:      9,
+		Unit:        "kPa",
+		Min:         0,
+		Max:         655350,
+		Decode:      func(r []byte) interface{} { return DecodeInjectionPressureControl(r) },
+	},
+	{
+		PID:         PIDTurboInletPressure,
+		Name:        "Turbocharger compressor inlet pressure",
+		ShortName:   "TURBO_INLET_PRESSURE",
+		Description: "Turbocharger compressor inlet pressure of sensors A and B",
+		Length:      3,
+		Unit:        "kPa",
+		Min:         0,
+		Max:         255,
+		Decode:      func(r []byte) interface{} { return DecodeTurboInletPressure(r) },
+	},
+	{
+		PID:         PIDBoostPressureControl,
+		Name:        "Boost pressure control",
+		ShortName:   "BOOST_PRESSURE_CONTROL",
+		Description: "Commanded and actual boost pressure for A and B",
+		Length:      10,
+		Unit:        "kPa",
+		Min:         0,
+		Max:         2047.96875,
+		Decode:      func(r []byte) interface{} { return DecodeBoostPressureControl(r) },
+	},
+	{
+		PID:         PIDVGTControl,
+		Name:        "Variable geometry turbo control",
+		ShortName:   "VGT_CONTROL",
+		Description: "Commanded and actual variable geometry turbo position for A and B",
+		Length:      6,
+		Unit:        "%",
+		Min:         0,
+		Max:         1,
+		Decode:      func(r []byte) interface{} { return DecodePercentSensors(r) },
+	},
+	{
+		PID:         PIDWastegateControl,
+		Name:        "Wastegate control",
+		ShortName:   "WASTEGATE_CONTROL",
+		Description: "Commanded and actual wastegate position for A and B",
+		Length:      5,
+		Unit:        "%",
+		Min:         0,
+		Max:         1,
+		Decode:      func(r []byte) interface{} { return DecodePercentSensors(r) },
+	},
+	{
+		PID:         PIDExhaustPressure,
+		Name:        "Exhaust pressure",
+		ShortName:   "EXHAUST_PRESSURE",
+		Description: "Exhaust pressure of bank 1 and 2",
+		Length:      5,
+		Unit:        "kPa",
+		Min:         0,
+		Max:         655.35,
+		Decode:      func(r []byte) interface{} { return DecodeExhaustPressure(r) },
+	},
+	{
+		PID:         PIDTurboRPM,
+		Name:        "Turbocharger RPM",
+		ShortName:   "TURBO_RPM",
+		Description: "RPM of turbochargers A and B",
+		Length:      5,
+		Unit:        "rpm",
+		Min:         0,
+		Max:         65535,
+		Decode:      func(r []byte) interface{} { return DecodeTurboRPM(r) },
+	},
+	{
+		PID:         PIDTurboTempA,
+		Name:        "Turbocharger A temperature",
+		ShortName:   "TURBO_TEMP_A",
+		Description: "Compressor and turbine inlet and outlet temperatures of turbocharger A",
+		Length:      7,
+		Unit:        "°C",
+		Min:         -40,
+		Max:         6513.5,
+		Decode:      func(r []byte) interface{} { return DecodeTurboTemp(r) },
+	},
+	{
+		PID:         PIDTurboTempB,
+		Name:        "Turbocharger B temperature",
+		ShortName:   "TURBO_TEMP_B",
+		Description: "Compressor and turbine inlet and outlet temperatures of turbocharger B",
+		Length:      7,
+		Unit:        "°C",
+		Min:         -40,
+		Max:         6513.5,
+		Decode:      func(r []byte) interface{} { return DecodeTurboTemp(r) },
+	},
+	{
+		PID:         PIDChargeAirCoolerTemp,
+		Name:        "Charge air cooler temperature",
+		ShortName:   "CACT",
+		Description: "Charge air cooler temperature of bank 1 and 2, sensors 1 and 2",
+		Length:      5,
+		Unit:        "°C",
+		Min:         -40,
+		Max:         215,
+		Decode:      func(r []byte) interface{} { return DecodeBankTemps(r) },
+	},
+	{
+		PID:         PIDEGTBank1,
+		Name:        "Exhaust gas temperature bank 1",
+		ShortName:   "EGT_BANK_1",
+		Description: "Exhaust gas temperature of bank 1, sensors 1 to 4",
+		Length:      9,
+		Unit:        "°C",
+		Min:         -40,
+		Max:         6513.5,
+		Decode:      func(r []byte) interface{} { return DecodeEGT(r) },
+	},
+	{
+		PID:         PIDEGTBank2,
+		Name:        "Exhaust gas temperature bank 2",
+		ShortName:   "EGT_BANK_2",
+		Description: "Exhaust gas temperature of bank 2, sensors 1 to 4",
+		Length:      9,
+		Unit:        "°C",
+		Min:         -40,
+		Max:         6513.5,
+		Decode:      func(r []byte) interface{} { return DecodeEGT(r) },
+	},
+	{
+		PID:         PIDDPFBank1,
+		Name:        "Diesel particulate filter bank 1",
+		ShortName:   "DPF_BANK_1",
+		Description: "Diesel particulate filter delta, inlet and outlet pressure of bank 1",
+		Length:      7,
+		Unit:        "kPa",
+		Min:         0,
+		Max:         655.35,
+		Decode:      func(r []byte) interface{} { return DecodeDPFPressure(r) },
+	},
+	{
+		PID:         PIDDPFBank2,
+		Name:        "Diesel particulate filter bank 2",
+		ShortName:   "DPF_BANK_2",
+		Description: "Diesel particulate filter delta, inlet and outlet pressure of bank 2",
+		Length:      7,
+		Unit:        "kPa",
+		Min:         0,
+		Max:         655.35,
+		Decode:      func(r []byte) interface{} { return DecodeDPFPressure(r) },
+	},
+	{
+		PID:         PIDDPFTemp,
+		Name:        "Diesel particulate filter temperature",
+		ShortName:   "DPF_TEMP",
+		Description: "Diesel particulate filter inlet and outlet temperature of bank 1 and 2",
+		Length:      9,
+		Unit:        "°C",
+		Min:         -40,
+		Max:         6513.5,
+		Decode:      func(r []byte) interface{} { return DecodeDPFTemp(r) },
+	},
+	{
+		PID:         PIDNOxNTEStatus,
+		Name:        "NOx NTE control area status",
+		ShortName:   "NOX_NTE_STATUS",
+		Description: "NOx not-to-exceed control area status",
+		Length:      1,
+	},
+	{
+		PID:         PIDPMNTEStatus,
+		Name:        "PM NTE control area status",
+		ShortName:   "PM_NTE_STATUS",
+		Description: "PM not-to-exceed control area status",
+		Length:      1,
+	},
+	{
+		PID:         PIDEngineRunTime,
+		Name:        "Engine run time",
+		ShortName:   "ENGINE_RUN_TIME",
+		Description: "Total engine run time, idle run time and run time with PTO active",
+		Length:      13,
+		Unit:        "s",
+		Min:         0,
+		Max:         4294967295,
+		Decode:      func(r []byte) interface{} { return DecodeEngineRunTime(r) },
+	},
+	{
+		PID:         PIDSupport5,
+		Name:        "PIDs supported [81-A0]",
+		ShortName:   "PIDS_E",
+		Description: "Supported PIDs from 0x81 to 0xA0",
+		Length:      4,
+		Decode:      supportedPIDs(PIDSupport5),
+	},
+	{
+		PID:         PIDAECDRunTime1,
+		Name:        "Engine run time for AECD #1 to #5",
+		ShortName:   "AECD_RUN_TIME_1",
+		Description: "Engine run time for auxiliary emission control devices #1 to #5",
+		Length:      41,
+		Decode:      func(r []byte) interface{} { return DecodeAECDRunTime(r) },
+	},
+	{
+		PID:         PIDAECDRunTime2,
+		Name:        "Engine run time for AECD #6 to #10",
+		ShortName:   "AECD_RUN_TIME_2",
+		Description: "Engine run time for auxiliary emission control devices #6 to #10",
+		Length:      41,
+		Decode:      func(r []byte) interface{} { return DecodeAECDRunTime(r) },
+	},
+	{
+		PID:         PIDNOxSensor,
+		Name:        "NOx sensor",
+		ShortName:   "NOX_SENSOR",
+		Description: "NOx concentration of bank 1 and 2, sensor 1",
+		Length:      5,
+		Unit:        "ppm",
+		Min:         0,
+		Max:         65535,
+		Decode:      func(r []byte) interface{} { return DecodeNOxSensor(r) },
+	},
+	{
+		PID:         PIDManifoldSurfaceTemp,
+		Name:        "Manifold surface temperature",
+		ShortName:   "MANIFOLD_SURFACE_TEMP",
+		Description: "Manifold surface temperature",
+		Length:      1,
+		Unit:        "°C",
+		Min:         -40,
+		Max:         215,
+		Decode:      func(r []byte) interface{} { return DecodeManifoldSurfaceTemp(r[0]) },
+	},
+	{
+		PID:         PIDNOxReagentSystem,
+		Name:        "NOx reagent system",
+		ShortName:   "NOX_REAGENT_SYSTEM",
+		Description: "NOx reagent system status",
+		Length:      10,
+	},
+	{
+		PID:         PIDPMSensor,
+		Name:        "Particulate matter sensor",
+		ShortName:   "PM_SENSOR",
+		Description: "Particulate matter concentration of bank 1 and 2, sensor 1",
+		Length:      5,
+		Unit:        "mg/m³",
+		Min:         0,
+		Max:         819.1875,
+		Decode:      func(r []byte) interface{} { return DecodePMSensor(r) },
+	},
+	{
+		PID:         PIDIntakeMAPSensors,
+		Name:        "Intake manifold absolute pressure sensors",
+		ShortName:   "INTAKE_PRESSURE_SENSORS",
+		Description: "Intake manifold absolute pressure of sensors A and B",
+		Length:      5,
+		Unit:        "kPa",
+		Min:         0,
+		Max:         2047.96875,
+		Decode:      func(r []byte) interface{} { return DecodeIntakeMAPSensors(r) },
+	},
+	{
+		PID:         PIDSCRInducement,
+		Name:        "SCR inducement system",
+		ShortName:   "SCR_INDUCEMENT",
+		Description: "SCR inducement system status",
+		Length:      13,
+	},
+	{
+		PID:         PIDAECDRunTime3,
+		Name:        "Engine run time for AECD #11 to #15",
+		ShortName:   "AECD_RUN_TIME_3",
+		Description: "Engine run time for auxiliary emission control devices #11 to #15",
+		Length:      41,
+		Decode:      func(r []byte) interface{} { return DecodeAECDRunTime(r) },
+	},
+	{
+		PID:         PIDAECDRunTime4,
+		Name:        "Engine run time for AECD #16 to #20",
+		ShortName:   "AECD_RUN_TIME_4",
+		Description: "Engine run time for auxiliary emission control devices #16 to #20",
+		Length:      41,
+		Decode:      func(r []byte) interface{} { return DecodeAECDRunTime(r) },
+	},
+	{
+		PID:         PIDDieselAftertreatment,
+		Name:        "Diesel aftertreatment",
+		ShortName:   "DIESEL_AFTERTREATMENT",
+		Description: "Diesel aftertreatment status",
+		Length:      7,
+	},
+	{
+		PID:         PIDO2SensorWide,
+		Name:        "O2 sensor (wide range)",
+		ShortName:   "O2_SENSOR_WIDE",
+		Description: "Wide range oxygen sensor data",
+		Length:      17,
+	},
+	{
+		PID:         PIDThrottlePosG,
+		Name:        "Throttle position G",
+		ShortName:   "THROTTLE_POS_G",
+		Description: "Absolute throttle position G",
+		Length:      1,
+		Unit:        "%",
+		Min:         0,
+		Max:         1,
+		Decode:      func(r []byte) interface{} { return DecodeThrottlePos(r[0]) },
+	},
+	{
+		PID:         PIDFrictionTorque,
+		Name:        "Engine friction torque",
+		ShortName:   "FRICTION_TORQUE",
+		Description: "Engine friction percent torque",
+		Length:      1,
+		Unit:        "%",
+		Min:         -1.25,
+		Max:         1.3,
+		Decode:      func(r []byte) interface{} { return DecodeTorquePercent(r[0]) },
+	},
+	{
+		PID:         PIDPMSensorBanks,
+		Name:        "PM sensor bank 1 and 2",
+		ShortName:   "PM_SENSOR_BANKS",
+		Description: "Particulate matter sensor output for bank 1 and 2",
+		Length:      7,
+	},
+	{
+		PID:         PIDWWHOBDSystemInfo,
+		Name:        "WWH-OBD vehicle OBD system information",
+		ShortName:   "WWH_OBD_SYSTEM_INFO",
+		Description: "WWH-OBD vehicle OBD system information",
+		Length:      3,
+	},
+	{
+		PID:         PIDECUOBDSystemInfo,
+		Name:        "WWH-OBD ECU OBD system information",
+		ShortName:   "ECU_OBD_SYSTEM_INFO",
+		Description: "WWH-OBD ECU OBD system information",
+		Length:      5,
+	},
+	{
+		PID:         PIDFuelSystemControl,
+		Name:        "Fuel system control",
+		ShortName:   "FUEL_SYSTEM_CONTROL",
+		Description: "Fuel system control status",
+		Length:      2,
+	},
+	{
+		PID:         PIDWWHOBDCounters,
+		Name:        "WWH-OBD vehicle OBD counters",
+		ShortName:   "WWH_OBD_COUNTERS",
+		Description: "WWH-OBD vehicle OBD counters",
+		Length:      3,
+	},
+	{
+		PID:         PIDNOxWarning,
+		Name:        "NOx warning and inducement system",
+		ShortName:   "NOX_WARNING",
+		Description: "NOx warning and inducement system status",
+		Length:      12,
+	},
+	{
+		PID:         PIDEGTBank1Ext,
+		Name:        "Exhaust gas temperature bank 1 (sensors 5-8)",
+		ShortName:   "EGT_BANK_1_EXT",
+		Description: "Exhaust gas temperature of bank 1, sensors 5 to 8",
+		Length:      9,
+		Unit:        "°C",
+		Min:         -40,
+		Max:         6513.5,
+		Decode:      func(r []byte) interface{} { return DecodeEGT(r) },
+	},
+	{
+		PID:         PIDEGTBank2Ext,
+		Name:        "Exhaust gas temperature bank 2 (sensors 5-8)",
+		ShortName:   "EGT_BANK_2_EXT",
+		Description: "Exhaust gas temperature of bank 2, sensors 5 to 8",
+		Length:      9,
+		Unit:        "°C",
+		Min:         -40,
+		Max:         6513.5,
+		Decode:      func(r []byte) interface{} { return DecodeEGT(r) },
+	},
+	{
+		PID:         PIDHybridSystemData,
+		Name:        "Hybrid/EV system data",
+		ShortName:   "HYBRID_SYSTEM_DATA",
+		Description: "Hybrid/EV system data, battery and voltage",
+		Length:      6,
+	},
+	{
+		PID:         PIDDEFSensor,
+		Name:        "Diesel exhaust fluid sensor",
+		ShortName:   "DEF_SENSOR",
+		Description: "Diesel exhaust fluid sensor data",
+		Length:      4,
+	},
+	{
+		PID:         PIDO2SensorData,
+		Name:        "O2 sensor data",
+		ShortName:   "O2_SENSOR_DATA",
+		Description: "Oxygen sensor data",
+		Length:      17,
+	},
+	{
+		PID:         PIDFuelRate,
+		Name:        "Engine fuel rate",
+		ShortName:   "FUEL_RATE_ENGINE_VEHICLE",
+		Description: "Engine and vehicle fuel rate",
+		Length:      4,
+		Unit:        "g/s",
+		Min:         0,
+		Max:         1310.7,
+		Decode:      func(r []byte) interface{} { return DecodeFuelRate(r) },
+	},
+	{
+		PID:         PIDExhaustFlowRate,
+		Name:        "Engine exhaust flow rate",
+		ShortName:   "EXHAUST_FLOW_RATE",
+		Description: "Engine exhaust flow rate",
+		Length:      2,
+		Unit:        "kg/h",
+		Min:         0,
+		Max:         13107,
+		Decode:      func(r []byte) interface{} { return DecodeExhaustFlowRate(r) },
+	},
+	{
+		PID:         PIDFuelSystemUse,
+		Name:        "Fuel system percentage use",
+		ShortName:   "FUEL_SYSTEM_USE",
+		Description: "Fuel system percentage use",
+		Length:      9,
+	},
+	{
+		PID:         PIDSupport6,
+		Name:        "PIDs supported [A1-C0]",
+		ShortName:   "PIDS_F",
+		Description: "Supported PIDs from 0xA1 to 0xC0",
+		Length:      4,
+		Decode:      supportedPIDs(PIDSupport6),
+	},
+	{
+		PID:         PIDNOxSensorCorrected,
+		Name:        "NOx sensor corrected data",
+		ShortName:   "NOX_SENSOR_CORRECTED",
+		Description: "Corrected NOx sensor data",
+		Length:      9,
+	},
+	{
+		PID:         PIDCylinderFuelRate,
+		Name:        "Cylinder fuel rate",
+		ShortName:   "CYLINDER_FUEL_RATE",
+		Description: "Cylinder fuel rate",
+		Length:      2,
+		Unit:        "mg/stroke",
+		Min:         0,
+		Max:         2047.96875,
+		Decode:      func(r []byte) interface{} { return DecodeCylinderFuelRate(r) },
+	},
+	{
+		PID:         PIDEvapVaporPressureExt,
+		Name:        "Evap system vapor pressure data",
+		ShortName:   "EVAP_VAPOR_PRESSURE_EXT",
+		Description: "Evaporative system vapor pressure data",
+		Length:      9,
+	},
+	{
+		PID:         PIDTransmissionGear,
+		Name:        "Transmission actual gear",
+		ShortName:   "TRANSMISSION_GEAR",
+		Description: "Transmission actual gear and gear ratio",
+		Length:      4,
+		Decode:      func(r []byte) interface{} { return DecodeTransmissionGear(r) },
+	},
+	{
+		PID:         PIDDEFDosing,
+		Name:        "Commanded diesel exhaust fluid dosing",
+		ShortName:   "DEF_DOSING",
+		Description: "Commanded diesel exhaust fluid dosing",
+		Length:      4,
+		Unit:        "%",
+		Min:         0,
+		Max:         1.275,
+		Decode:      func(r []byte) interface{} { return DecodeDEFDosing(r) },
+	},
+	{
+		PID:         PIDOdometer,
+		Name:        "Odometer",
+		ShortName:   "ODOMETER",
+		Description: "Odometer reading",
+		Length:      4,
+		Unit:        "km",
+		Min:         0,
+		Max:         429496729.5,
+		Decode:      func(r []byte) interface{} { return DecodeOdometer(r) },
+	},
+	{
+		PID:         PIDNOxSensor34,
+		Name:        "NOx sensor 3 and 4",
+		ShortName:   "NOX_SENSOR_34",
+		Description: "NOx concentration of sensors 3 and 4",
+		Length:      0,
+	},
+	{
+		PID:         PIDNOxSensorCorrected34,
+		Name:        "NOx sensor 3 and 4 corrected",
+		ShortName:   "NOX_SENSOR_CORRECTED_34",
+		Description: "Corrected NOx concentration of sensors 3 and 4",
+		Length:      0,
+	},
+	{
+		PID:         PIDABSDisable,
+		Name:        "ABS disable switch state",
+		ShortName:   "ABS_DISABLE",
+		Description: "ABS disable switch state",
+		Length:      0,
+	},
+	{
+		PID:         PIDSupport7,
+		Name:        "PIDs supported [C1-E0]",
+		ShortName:   "PIDS_G",
+		Description: "Supported PIDs from 0xC1 to 0xE0",
+		Length:      4,
+		Decode:      supportedPIDs(PIDSupport7),
+	},
+	{
+		PID:         PIDDriveCondition,
+		Name:        "Drive condition",
+		ShortName:   "DRIVE_CONDITION",
+		Description: "Drive condition data (not standardized)",
+		Length:      0,
+	},
+	{
+		PID:         PIDEngineIdleStopRequest,
+		Name:        "Engine idle and stop request",
+		ShortName:   "ENGINE_IDLE_STOP_REQUEST",
+		Description: "Engine idle and stop requests (not standardized)",
+		Length:      0,
+	},
+	{
+		PID:         PIDSupport8,
+		Name:        "PIDs supported [E1-FF]",
+		ShortName:   "PIDS_H",
+		Description: "Supported PIDs from 0xE1 to 0xFF",
+		Length:      4,
+		Decode:      supportedPIDs(PIDSupport8),
+	},
+}
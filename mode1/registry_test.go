@@ -0,0 +1,184 @@
+package mode1
+
+import (
+	"testing"
+	"time"
+)
+
+// TestEncodeRegistry will encode a response for every decoded PID in the registry, and check that it has the
+// registered length and decodes without error
+func TestEncodeRegistry(t *testing.T) {
+	b := func(v ...byte) []byte { return v }
+	on := func(v float64) SensorValue { return SensorValue{Supported: true, Value: v} }
+	pair := SensorPair{A: on(20), B: on(30)}
+	banks := BankPair{Bank1: on(20), Bank2: on(30)}
+	control := ControlPair{A: ControlValue{Commanded: on(0.5), Actual: on(0.4)}}
+	temps := BankSensors{Bank1: [4]SensorValue{on(20), on(21), on(22)}, Bank2: [4]SensorValue{on(23), on(24), on(25)}}
+	status := MonitorStatus{MIL: true, DTCCount: 2, Spark: &MonitorStatusSpark{}}
+	aecd := []AECDRunTime{{Supported: true, Timer1: time.Hour, Timer2: time.Minute}}
+
+	responses := map[byte][]byte{
+		PIDSupport1:                  b(0xbe, 0x1f, 0xa8, 0x13),
+		PIDMonitorStatus:             EncodeMonitorStatus(status),
+		PIDFuelSystemStatus:          b(byte(FuelSystemStatusClosed), 0),
+		PIDEngineLoad:                b(EncodeEngineLoad(0.2)),
+		PIDECT:                       b(EncodeECT(90)),
+		PIDSTFTBank1:                 b(EncodeFuelTrim(0)),
+		PIDLTFTBank1:                 b(EncodeFuelTrim(0)),
+		PIDSTFTBank2:                 b(EncodeFuelTrim(0)),
+		PIDLTFTBank2:                 b(EncodeFuelTrim(0)),
+		PIDFuelPressure:              b(EncodeFuelPressure(300)),
+		PIDIntakeMAP:                 b(33),
+		PIDEngineRPM:                 EncodeEngineRPM(750),
+		PIDVehicleSpeed:              b(50),
+		PIDTimingAdvance:             b(EncodeTimingAdvance(10)),
+		PIDIAT:                       b(EncodeIAT(25)),
+		PIDMAFRate:                   EncodeMAFRate(3.5),
+		PIDThrottlePos:               b(EncodeThrottlePos(0.15)),
+		PIDComAirStatus:              b(byte(ComAirStatusOff)),
+		PIDO2Present:                 b(EncodeO2Present(O2Present{Bank1: [4]bool{true, true}})),
+		PIDO2STFT1:                   EncodeO2STFT(O2STFT{Voltage: 0.45, SensorUsed: true}),
+		PIDO2STFT2:                   EncodeO2STFT(O2STFT{Voltage: 0.45}),
+		PIDO2STFT3:                   EncodeO2STFT(O2STFT{Voltage: 0.45}),
+		PIDO2STFT4:                   EncodeO2STFT(O2STFT{Voltage: 0.45}),
+		PIDO2STFT5:                   EncodeO2STFT(O2STFT{Voltage: 0.45}),
+		PIDO2STFT6:                   EncodeO2STFT(O2STFT{Voltage: 0.45}),
+		PIDO2STFT7:                   EncodeO2STFT(O2STFT{Voltage: 0.45}),
+		PIDO2STFT8:                   EncodeO2STFT(O2STFT{Voltage: 0.45}),
+		PIDOBDStandard:               b(byte(OBDStandardOBD2CARB)),
+		PIDAuxInput:                  b(EncodeAuxInput(AuxInputStatus{})),
+		PIDRunTime:                   EncodeRunTime(time.Minute),
+		PIDSupport2:                  b(0x80, 0, 0, 1),
+		PIDDistanceMIL:               EncodeDistance(0),
+		PIDFuelRailPressureRelative:  EncodeFuelRailPressureRelative(300),
+		PIDFuelRailPressure:          EncodeFuelRailPressure(5000),
+		PIDO2WRVoltage1:              EncodeO2WRVoltage(O2WRVoltage{Lambda: 1, Voltage: 0.5}),
+		PIDO2WRVoltage2:              EncodeO2WRVoltage(O2WRVoltage{Lambda: 1, Voltage: 0.5}),
+		PIDO2WRVoltage3:              EncodeO2WRVoltage(O2WRVoltage{Lambda: 1, Voltage: 0.5}),
+		PIDO2WRVoltage4:              EncodeO2WRVoltage(O2WRVoltage{Lambda: 1, Voltage: 0.5}),
+		PIDO2WRVoltage5:              EncodeO2WRVoltage(O2WRVoltage{Lambda: 1, Voltage: 0.5}),
+		PIDO2WRVoltage6:              EncodeO2WRVoltage(O2WRVoltage{Lambda: 1, Voltage: 0.5}),
+		PIDO2WRVoltage7:              EncodeO2WRVoltage(O2WRVoltage{Lambda: 1, Voltage: 0.5}),
+		PIDO2WRVoltage8:              EncodeO2WRVoltage(O2WRVoltage{Lambda: 1, Voltage: 0.5}),
+		PIDCommandedEGR:              b(EncodeCommandedEGR(0.1)),
+		PIDEGRError:                  b(EncodeEGRError(0)),
+		PIDEvapPurge:                 b(EncodeEvapPurge(0.1)),
+		PIDFuelTankLevel:             b(EncodeFuelTankLevel(0.5)),
+		PIDWarmUps:                   b(EncodeWarmUps(3)),
+		PIDDistanceSinceClear:        EncodeDistance(120),
+		PIDEvapVaporPressure:         EncodeEvapVaporPressure(-100),
+		PIDBarometricPressure:        b(EncodeBarometricPressure(101)),
+		PIDO2WRCurrent1:              EncodeO2WRCurrent(O2WRCurrent{Lambda: 1}),
+		PIDO2WRCurrent2:              EncodeO2WRCurrent(O2WRCurrent{Lambda: 1}),
+		PIDO2WRCurrent3:              EncodeO2WRCurrent(O2WRCurrent{Lambda: 1}),
+		PIDO2WRCurrent4:              EncodeO2WRCurrent(O2WRCurrent{Lambda: 1}),
+		PIDO2WRCurrent5:              EncodeO2WRCurrent(O2WRCurrent{Lambda: 1}),
+		PIDO2WRCurrent6:              EncodeO2WRCurrent(O2WRCurrent{Lambda: 1}),
+		PIDO2WRCurrent7:              EncodeO2WRCurrent(O2WRCurrent{Lambda: 1}),
+		PIDO2WRCurrent8:              EncodeO2WRCurrent(O2WRCurrent{Lambda: 1}),
+		PIDCatalystTempB1S1:          EncodeCatalystTemp(600),
+		PIDCatalystTempB2S1:          EncodeCatalystTemp(600),
+		PIDCatalystTempB1S2:          EncodeCatalystTemp(550),
+		PIDCatalystTempB2S2:          EncodeCatalystTemp(550),
+		PIDSupport3:                  b(0xfe, 0xd0, 0, 1),
+		PIDMonitorStatusDriveCycle:   EncodeMonitorStatus(status),
+		PIDControlModuleVoltage:      EncodeControlModuleVoltage(14.1),
+		PIDAbsoluteLoad:              EncodeAbsoluteLoad(0.25),
+		PIDCommandedEquivRatio:       EncodeCommandedEquivRatio(1),
+		PIDRelativeThrottlePos:       b(EncodeThrottlePos(0.05)),
+		PIDAmbientAirTemp:            b(EncodeAmbientAirTemp(20)),
+		PIDAbsoluteThrottlePosB:      b(EncodeThrottlePos(0.15)),
+		PIDAbsoluteThrottlePosC:      b(EncodeThrottlePos(0.15)),
+		PIDAccelPedalPosD:            b(EncodePedalPos(0.15)),
+		PIDAccelPedalPosE:            b(EncodePedalPos(0.15)),
+		PIDAccelPedalPosF:            b(EncodePedalPos(0.15)),
+		PIDCommandedThrottleActuator: b(EncodeCommandedThrottleActuator(0.1)),
+		PIDTimeMIL:                   EncodeMinutes(0),
+		PIDTimeSinceClear:            EncodeMinutes(time.Hour),
+		PIDMaxValues:                 EncodeMaxValues(MaxValues{EquivRatio: 2, O2Voltage: 8, O2Current: 128, IntakeMAP: 255}),
+		PIDMaxMAFRate:                EncodeMaxMAFRate(650),
+		PIDFuelType:                  b(byte(FuelTypeGasoline)),
+		PIDEthanolPercent:            b(EncodeEthanolPercent(0.1)),
+		PIDEvapPressureAbsolute:      EncodeEvapPressureAbsolute(101),
+		PIDEvapVaporPressureWide:     EncodeEvapVaporPressureWide(-300),
+		PIDSecondaryO2STTBank13:      b(EncodeFuelTrim(0), EncodeFuelTrim(0)),
+		PIDSecondaryO2LTTBank13:      b(EncodeFuelTrim(0), EncodeFuelTrim(0)),
+		PIDSecondaryO2STTBank24:      b(EncodeFuelTrim(0), EncodeFuelTrim(0)),
+		PIDSecondaryO2LTTBank24:      b(EncodeFuelTrim(0), EncodeFuelTrim(0)),
+		PIDFuelRailPressureAbsolute:  EncodeFuelRailPressure(5000),
+		PIDRelativePedalPos:          b(EncodePedalPos(0.1)),
+		PIDHybridBatteryLife:         b(EncodeHybridBatteryLife(0.8)),
+		PIDEngineOilTemp:             b(EncodeEngineOilTemp(95)),
+		PIDFuelInjectionTiming:       EncodeFuelInjectionTiming(-5),
+		PIDEngineFuelRate:            EncodeEngineFuelRate(2.5),
+		PIDSupport4:                  b(0xff, 0xff, 0xff, 0xff),
+		PIDDriverDemandTorque:        b(EncodeTorquePercent(0.2)),
+		PIDActualTorque:              b(EncodeTorquePercent(0.2)),
+		PIDReferenceTorque:           EncodeReferenceTorque(400),
+		PIDTorqueData:                EncodeTorqueData(TorqueData{Idle: 0.1}),
+		PIDAuxIO:                     EncodeAuxIO(AuxIO{PTO: SensorState{Supported: true}}),
+		PIDMAFSensors:                EncodeMAFSensors(pair),
+		PIDECTSensors:                EncodeECTSensors(pair),
+		PIDIATSensors:                EncodeBankTemps(temps, 3),
+		PIDEGRControl:                EncodeEGRControl(EGRControl{A: EGRValve{Commanded: on(0.2), Actual: on(0.2), Error: on(0)}}),
+		PIDIntakeAirFlowControl:      EncodePercentSensors(control),
+		PIDEGRTemp:                   EncodeBankTemps(temps, 2),
+		PIDThrottleActuatorControl:   EncodePercentSensors(control),
+		PIDFuelPressureControl:       EncodeFuelPressureControl(FuelPressureControl{System1: FuelSystemPressure{Commanded: on(30000)}}),
+		PIDInjectionPressureControl:  EncodeInjectionPressureControl(control),
+		PIDTurboInletPressure:        EncodeTurboInletPressure(pair),
+		PIDBoostPressureControl:      EncodeBoostPressureControl(control),
+		PIDVGTControl:                EncodeVGTControl(control),
+		PIDWastegateControl:          EncodePercentSensors(control),
+		PIDExhaustPressure:           EncodeExhaustPressure(banks),
+		PIDTurboRPM:                  EncodeTurboRPM(pair),
+		PIDTurboTempA:                EncodeTurboTemp(TurboTemp{CompressorInlet: on(20)}),
+		PIDTurboTempB:                EncodeTurboTemp(TurboTemp{CompressorInlet: on(20)}),
+		PIDChargeAirCoolerTemp:       EncodeBankTemps(temps, 2),
+		PIDEGTBank1:                  EncodeEGT(EGT{Sensors: [4]SensorValue{on(400)}}),
+		PIDEGTBank2:                  EncodeEGT(EGT{Sensors: [4]SensorValue{on(400)}}),
+		PIDDPFBank1:                  EncodeDPFPressure(DPFPressure{Delta: on(2)}),
+		PIDDPFBank2:                  EncodeDPFPressure(DPFPressure{Delta: on(2)}),
+		PIDDPFTemp:                   EncodeDPFTemp(DPFTemp{Bank1Inlet: on(300)}),
+		PIDEngineRunTime:             EncodeEngineRunTime(EngineRunTime{Total: on(3600)}),
+		PIDSupport5:                  b(0, 0, 0, 1),
+		PIDAECDRunTime1:              EncodeAECDRunTime(aecd),
+		PIDAECDRunTime2:              EncodeAECDRunTime(aecd),
+		PIDNOxSensor:                 EncodeNOxSensor(banks),
+		PIDManifoldSurfaceTemp:       b(EncodeManifoldSurfaceTemp(80)),
+		PIDPMSensor:                  EncodePMSensor(banks),
+		PIDIntakeMAPSensors:          EncodeIntakeMAPSensors(pair),
+		PIDAECDRunTime3:              EncodeAECDRunTime(aecd),
+		PIDAECDRunTime4:              EncodeAECDRunTime(aecd),
+		PIDThrottlePosG:              b(EncodeThrottlePos(0.15)),
+		PIDFrictionTorque:            b(EncodeTorquePercent(0.05)),
+		PIDEGTBank1Ext:               EncodeEGT(EGT{Sensors: [4]SensorValue{on(400)}}),
+		PIDEGTBank2Ext:               EncodeEGT(EGT{Sensors: [4]SensorValue{on(400)}}),
+		PIDFuelRate:                  EncodeFuelRate(FuelRate{Engine: 2, Vehicle: 2}),
+		PIDExhaustFlowRate:           EncodeExhaustFlowRate(120),
+		PIDSupport6:                  b(0, 0, 0, 1),
+		PIDCylinderFuelRate:          EncodeCylinderFuelRate(20),
+		PIDTransmissionGear:          EncodeTransmissionGear(TransmissionGear{Supported: true, Gear: 3, Ratio: 1.5}),
+		PIDDEFDosing:                 EncodeDEFDosing(on(0.5)),
+		PIDOdometer:                  EncodeOdometer(12345.6),
+		PIDSupport7:                  b(0, 0, 0, 1),
+		PIDSupport8:                  b(0, 0, 0, 0),
+	}
+
+	for _, info := range pidInfos {
+		if info.Decode == nil {
+			continue
+		}
+		res, ok := responses[info.PID]
+		if !ok {
+			t.Errorf("PID 0x%02x (%s): no encoded response", info.PID, info.ShortName)
+			continue
+		}
+		if len(res) != info.Length {
+			t.Errorf("PID 0x%02x (%s): encoded %d bytes; registry length is %d", info.PID, info.ShortName, len(res), info.Length)
+		}
+		if _, err := Decode(info.PID, res); err != nil {
+			t.Errorf("PID 0x%02x (%s): %v", info.PID, info.ShortName, err)
+		}
+	}
+}
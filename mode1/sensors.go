@@ -0,0 +1,478 @@
+package mode1
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// SensorValue is the reading of one sensor, for PIDs that report several
+type SensorValue struct {
+	// Supported is set if the ECU reports the sensor as supported. Value should be ignored otherwise
+	Supported bool
+
+	// Value is the reading, in the unit documented by the decoder
+	Value float64
+}
+
+// decodeSensors will decode n values of size bytes each, following the support bit mask in res[0]
+func decodeSensors(res []byte, n, size int, conv func(raw uint32) float64) []SensorValue {
+	vals := make([]SensorValue, n)
+	for i := range vals {
+		vals[i].Supported = res[0]&(1<<uint(i)) != 0
+		var raw uint32
+		for _, b := range res[1+i*size : 1+(i+1)*size] {
+			raw = raw<<8 | uint32(b)
+		}
+		vals[i].Value = conv(raw)
+	}
+	return vals
+}
+
+func tempByte(raw uint32) float64    { return float64(raw) - 40 }
+func tempWord(raw uint32) float64    { return float64(raw)/10 - 40 }
+func percentByte(raw uint32) float64 { return float64(raw) / 255 }
+
+// DecodeTorquePercent will decode a percent torque value (between -1.25 and 1.30)
+func DecodeTorquePercent(v byte) float64 {
+	return float64(v)/100 - 1.25
+}
+
+// DecodeReferenceTorque will return the engine reference torque in Nm. res must be 2 bytes
+func DecodeReferenceTorque(res []byte) int {
+	return int(binary.BigEndian.Uint16(res))
+}
+
+// TorqueData is the decoded result of PIDTorqueData. Each value is a percent torque (between -1.25 and 1.30)
+type TorqueData struct {
+	// Idle is the percent torque at idle
+	Idle float64
+
+	// Points is the percent torque at engine points 1 to 4
+	Points [4]float64
+}
+
+// DecodeTorqueData will decode the response for PIDTorqueData. res must be 5 bytes
+func DecodeTorqueData(res []byte) TorqueData {
+	var t TorqueData
+	t.Idle = DecodeTorquePercent(res[0])
+	for i := range t.Points {
+		t.Points[i] = DecodeTorquePercent(res[i+1])
+	}
+	return t
+}
+
+// SensorState is the state of one input, for PIDs that report several
+type SensorState struct {
+	// Supported is set if the ECU reports the input as supported
+	Supported bool
+
+	// Active is set if the input is on
+	Active bool
+}
+
+// AuxIO is the decoded result of PIDAuxIO
+type AuxIO struct {
+	// PTO is set if Power Take Off is active
+	PTO SensorState
+
+	// AutoTransNeutral is set if an automatic transmission is in neutral (or park)
+	AutoTransNeutral SensorState
+
+	// ManualTransNeutral is set if a manual transmission is in neutral (or the clutch is depressed)
+	ManualTransNeutral SensorState
+
+	// GlowPlugLamp is set if the glow plug lamp is on
+	GlowPlugLamp SensorState
+}
+
+// DecodeAuxIO will decode the response for PIDAuxIO. res must be 2 bytes
+func DecodeAuxIO(res []byte) AuxIO {
+	state := func(bit uint) SensorState {
+		return SensorState{Supported: res[0]&(1<<bit) != 0, Active: res[1]&(1<<bit) != 0}
+	}
+	return AuxIO{
+		PTO:                state(0),
+		AutoTransNeutral:   state(1),
+		ManualTransNeutral: state(2),
+		GlowPlugLamp:       state(3),
+	}
+}
+
+// available will return true if any of vals is supported
+func available(vals ...SensorValue) bool {
+	for _, v := range vals {
+		if v.Supported {
+			return true
+		}
+	}
+	return false
+}
+
+// SensorPair is the reading of sensors A and B (or sensors 1 and 2)
+type SensorPair struct {
+	A SensorValue
+	B SensorValue
+}
+
+func (p SensorPair) available() bool { return available(p.A, p.B) }
+
+// BankPair is the reading of one sensor on bank 1 and bank 2
+type BankPair struct {
+	Bank1 SensorValue
+	Bank2 SensorValue
+}
+
+func (p BankPair) available() bool { return available(p.Bank1, p.Bank2) }
+
+// BankSensors is the reading of numbered sensors on bank 1 and 2. Index 0 is sensor 1. Sensors a PID does not
+// report are never supported
+type BankSensors struct {
+	Bank1 [4]SensorValue
+	Bank2 [4]SensorValue
+}
+
+func (s BankSensors) available() bool { return available(append(s.Bank1[:], s.Bank2[:]...)...) }
+
+// ControlValue is the commanded and actual value of something the ECU controls
+type ControlValue struct {
+	Commanded SensorValue
+	Actual    SensorValue
+}
+
+// ControlPair is the commanded and actual values of A and B
+type ControlPair struct {
+	A ControlValue
+	B ControlValue
+}
+
+func (p ControlPair) available() bool {
+	return available(p.A.Commanded, p.A.Actual, p.B.Commanded, p.B.Actual)
+}
+
+// DecodeMAFSensors will decode the mass air flow of sensors A and B in grams/sec. res must be 5 bytes
+func DecodeMAFSensors(res []byte) SensorPair {
+	v := decodeSensors(res, 2, 2, func(raw uint32) float64 { return float64(raw) / 32 })
+	return SensorPair{A: v[0], B: v[1]}
+}
+
+// DecodeECTSensors will decode the engine coolant temperature of sensors 1 (A) and 2 (B) in degrees Celsius. res must be 3 bytes
+func DecodeECTSensors(res []byte) SensorPair {
+	v := decodeSensors(res, 2, 1, tempByte)
+	return SensorPair{A: v[0], B: v[1]}
+}
+
+// DecodeBankTemps will decode temperatures in degrees Celsius of the same number of sensors on bank 1 and 2, one byte per
+// sensor following the support byte (3 per bank for PIDIATSensors, 2 per bank for PIDEGRTemp and PIDChargeAirCoolerTemp).
+// res must be 3, 5 or 7 bytes, anything past 3 sensors per bank is ignored
+func DecodeBankTemps(res []byte) BankSensors {
+	n := (len(res) - 1) / 2
+	if n > 3 {
+		n = 3
+	}
+	v := decodeSensors(res, n*2, 1, tempByte)
+	var s BankSensors
+	copy(s.Bank1[:], v[:n])
+	copy(s.Bank2[:], v[n:])
+	return s
+}
+
+// EGRValve is the duty cycle of one EGR valve
+type EGRValve struct {
+	// Commanded and Actual are the duty cycle as a percentage (between 0 and 1)
+	Commanded SensorValue
+	Actual    SensorValue
+
+	// Error is the difference from commanded as a percentage of commanded (-1 to 1)
+	Error SensorValue
+}
+
+// EGRControl is the decoded result of PIDEGRControl
+type EGRControl struct {
+	A EGRValve
+	B EGRValve
+}
+
+func (c EGRControl) available() bool {
+	return available(c.A.Commanded, c.A.Actual, c.A.Error, c.B.Commanded, c.B.Actual, c.B.Error)
+}
+
+// DecodeEGRControl will decode the commanded and actual duty cycle, and error, of EGR A and B. res must be 7 bytes
+func DecodeEGRControl(res []byte) EGRControl {
+	v := decodeSensors(res, 6, 1, percentByte)
+	for _, i := range []int{2, 5} {
+		v[i].Value = float64(res[i+1])/128 - 1
+	}
+	return EGRControl{
+		A: EGRValve{Commanded: v[0], Actual: v[1], Error: v[2]},
+		B: EGRValve{Commanded: v[3], Actual: v[4], Error: v[5]},
+	}
+}
+
+// DecodePercentSensors will decode commanded and actual/relative positions as a percentage (between 0 and 1) for A and B
+// (for PIDIntakeAirFlowControl, PIDThrottleActuatorControl, PIDVGTControl and PIDWastegateControl). res must be at least 5 bytes
+func DecodePercentSensors(res []byte) ControlPair {
+	return decodeControlPair(res, 1, percentByte)
+}
+
+// decodeControlPair will decode commanded and actual values for A and B, of size bytes each
+func decodeControlPair(res []byte, size int, conv func(raw uint32) float64) ControlPair {
+	v := decodeSensors(res, 4, size, conv)
+	return ControlPair{
+		A: ControlValue{Commanded: v[0], Actual: v[1]},
+		B: ControlValue{Commanded: v[2], Actual: v[3]},
+	}
+}
+
+// FuelSystemPressure is the rail pressure and fuel temperature of one fuel system
+type FuelSystemPressure struct {
+	// Commanded and Actual are the fuel rail pressure in kPa
+	Commanded SensorValue
+	Actual    SensorValue
+
+	// Temp is the fuel temperature in degrees Celsius
+	Temp SensorValue
+}
+
+// FuelPressureControl is the decoded result of PIDFuelPressureControl
+type FuelPressureControl struct {
+	System1 FuelSystemPressure
+	System2 FuelSystemPressure
+}
+
+func (c FuelPressureControl) available() bool {
+	return available(c.System1.Commanded, c.System1.Actual, c.System1.Temp, c.System2.Commanded, c.System2.Actual, c.System2.Temp)
+}
+
+// DecodeFuelPressureControl will decode the commanded and actual rail pressure, and fuel temperature, of fuel systems 1 and 2.
+// res must be 11 bytes
+func DecodeFuelPressureControl(res []byte) FuelPressureControl {
+	system := func(i int) FuelSystemPressure {
+		data := res[1+i*5:]
+		return FuelSystemPressure{
+			Commanded: SensorValue{Supported: res[0]&(1<<uint(i*3)) != 0, Value: float64(binary.BigEndian.Uint16(data)) * 10},
+			Actual:    SensorValue{Supported: res[0]&(1<<uint(i*3+1)) != 0, Value: float64(binary.BigEndian.Uint16(data[2:])) * 10},
+			Temp:      SensorValue{Supported: res[0]&(1<<uint(i*3+2)) != 0, Value: float64(data[4]) - 40},
+		}
+	}
+	return FuelPressureControl{System1: system(0), System2: system(1)}
+}
+
+// DecodeInjectionPressureControl will decode the commanded and actual injection control pressure for A and B, in kPa. res must be 9 bytes
+func DecodeInjectionPressureControl(res []byte) ControlPair {
+	return decodeControlPair(res, 2, func(raw uint32) float64 { return float64(raw) * 10 })
+}
+
+// DecodeTurboInletPressure will decode the turbocharger compressor inlet pressure of sensors A and B, in kPa. res must be 3 bytes
+func DecodeTurboInletPressure(res []byte) SensorPair {
+	v := decodeSensors(res, 2, 1, func(raw uint32) float64 { return float64(raw) })
+	return SensorPair{A: v[0], B: v[1]}
+}
+
+// DecodeBoostPressureControl will decode the commanded and actual boost pressure for A and B, in kPa. res must be at least 9 bytes
+func DecodeBoostPressureControl(res []byte) ControlPair {
+	return decodeControlPair(res, 2, func(raw uint32) float64 { return float64(raw) * 0.03125 })
+}
+
+// DecodeExhaustPressure will decode the exhaust pressure of bank 1 and 2, in kPa. res must be 5 bytes
+func DecodeExhaustPressure(res []byte) BankPair {
+	v := decodeSensors(res, 2, 2, func(raw uint32) float64 { return float64(raw) * 0.01 })
+	return BankPair{Bank1: v[0], Bank2: v[1]}
+}
+
+// DecodeTurboRPM will decode the RPM of turbochargers A and B. res must be 5 bytes
+func DecodeTurboRPM(res []byte) SensorPair {
+	v := decodeSensors(res, 2, 2, func(raw uint32) float64 { return float64(raw) })
+	return SensorPair{A: v[0], B: v[1]}
+}
+
+// TurboTemp is the decoded result of PIDTurboTempA and PIDTurboTempB. Temperatures are in degrees Celsius
+type TurboTemp struct {
+	CompressorInlet  SensorValue
+	CompressorOutlet SensorValue
+	TurbineInlet     SensorValue
+	TurbineOutlet    SensorValue
+}
+
+func (t TurboTemp) available() bool {
+	return available(t.CompressorInlet, t.CompressorOutlet, t.TurbineInlet, t.TurbineOutlet)
+}
+
+// DecodeTurboTemp will decode the compressor and turbine inlet and outlet temperatures of a turbocharger. res must be 7 bytes
+func DecodeTurboTemp(res []byte) TurboTemp {
+	supported := func(i uint) bool { return res[0]&(1<<i) != 0 }
+	return TurboTemp{
+		CompressorInlet:  SensorValue{Supported: supported(0), Value: tempByte(uint32(res[1]))},
+		CompressorOutlet: SensorValue{Supported: supported(1), Value: tempByte(uint32(res[2]))},
+		TurbineInlet:     SensorValue{Supported: supported(2), Value: tempWord(uint32(binary.BigEndian.Uint16(res[3:])))},
+		TurbineOutlet:    SensorValue{Supported: supported(3), Value: tempWord(uint32(binary.BigEndian.Uint16(res[5:])))},
+	}
+}
+
+// EGT is the decoded result of the exhaust gas temperature PIDs
+type EGT struct {
+	// Sensors are the temperatures in degrees Celsius of the 4 sensors reported by the PID, in order (sensors 1 to 4,
+	// or 5 to 8 for PIDEGTBank1Ext and PIDEGTBank2Ext)
+	Sensors [4]SensorValue
+}
+
+func (e EGT) available() bool { return available(e.Sensors[:]...) }
+
+// DecodeEGT will decode exhaust gas temperatures of 4 sensors on one bank. res must be 9 bytes
+func DecodeEGT(res []byte) EGT {
+	var e EGT
+	copy(e.Sensors[:], decodeSensors(res, 4, 2, tempWord))
+	return e
+}
+
+// DPFTemp is the decoded result of PIDDPFTemp. Temperatures are in degrees Celsius
+type DPFTemp struct {
+	Bank1Inlet  SensorValue
+	Bank1Outlet SensorValue
+	Bank2Inlet  SensorValue
+	Bank2Outlet SensorValue
+}
+
+func (t DPFTemp) available() bool {
+	return available(t.Bank1Inlet, t.Bank1Outlet, t.Bank2Inlet, t.Bank2Outlet)
+}
+
+// DecodeDPFTemp will decode the diesel particulate filter inlet and outlet temperature of bank 1 and 2. res must be 9 bytes
+func DecodeDPFTemp(res []byte) DPFTemp {
+	v := decodeSensors(res, 4, 2, tempWord)
+	return DPFTemp{Bank1Inlet: v[0], Bank1Outlet: v[1], Bank2Inlet: v[2], Bank2Outlet: v[3]}
+}
+
+// DPFPressure is the decoded result of PIDDPFBank1 and PIDDPFBank2. Pressures are in kPa
+type DPFPressure struct {
+	Delta  SensorValue
+	Inlet  SensorValue
+	Outlet SensorValue
+}
+
+func (p DPFPressure) available() bool { return available(p.Delta, p.Inlet, p.Outlet) }
+
+// DecodeDPFPressure will decode the diesel particulate filter delta, inlet and outlet pressure. res must be 7 bytes
+func DecodeDPFPressure(res []byte) DPFPressure {
+	v := decodeSensors(res, 3, 2, func(raw uint32) float64 { return float64(raw) * 0.01 })
+	return DPFPressure{Delta: v[0], Inlet: v[1], Outlet: v[2]}
+}
+
+// EngineRunTime is the decoded result of PIDEngineRunTime. Times are in seconds
+type EngineRunTime struct {
+	Total SensorValue
+	Idle  SensorValue
+	PTO   SensorValue
+}
+
+func (t EngineRunTime) available() bool { return available(t.Total, t.Idle, t.PTO) }
+
+// DecodeEngineRunTime will decode the total engine run time, total idle run time, and total run time with PTO active. res must be 13 bytes
+func DecodeEngineRunTime(res []byte) EngineRunTime {
+	v := decodeSensors(res, 3, 4, func(raw uint32) float64 { return float64(raw) })
+	return EngineRunTime{Total: v[0], Idle: v[1], PTO: v[2]}
+}
+
+// AECDRunTime is the run time of a single AECD (auxiliary emission control device)
+type AECDRunTime struct {
+	// Supported is set if the ECU reports the AECD as supported
+	Supported bool
+
+	// Timer1 is the total run time with the AECD active
+	Timer1 time.Duration
+
+	// Timer2 is the total run time with the AECD active, for AECDs with a second timer
+	Timer2 time.Duration
+}
+
+// DecodeAECDRunTime will decode the run time of 5 AECDs (for PIDAECDRunTime1 to PIDAECDRunTime4). res must be 41 bytes
+func DecodeAECDRunTime(res []byte) []AECDRunTime {
+	vals := make([]AECDRunTime, 5)
+	for i := range vals {
+		data := res[1+i*8:]
+		vals[i].Supported = res[0]&(1<<uint(i)) != 0
+		vals[i].Timer1 = time.Duration(binary.BigEndian.Uint32(data)) * time.Second
+		vals[i].Timer2 = time.Duration(binary.BigEndian.Uint32(data[4:])) * time.Second
+	}
+	return vals
+}
+
+// DecodeNOxSensor will decode the NOx concentration of bank 1 and 2, sensor 1, in ppm. res must be 5 bytes
+func DecodeNOxSensor(res []byte) BankPair {
+	v := decodeSensors(res, 2, 2, func(raw uint32) float64 { return float64(raw) })
+	return BankPair{Bank1: v[0], Bank2: v[1]}
+}
+
+// DecodeManifoldSurfaceTemp will convert the response for PIDManifoldSurfaceTemp to degrees Celsius
+func DecodeManifoldSurfaceTemp(v byte) int {
+	return int(v) - 40
+}
+
+// DecodePMSensor will decode the particulate matter concentration of bank 1 and 2, sensor 1, in mg/m³. res must be 5 bytes
+func DecodePMSensor(res []byte) BankPair {
+	v := decodeSensors(res, 2, 2, func(raw uint32) float64 { return float64(raw) * 0.0125 })
+	return BankPair{Bank1: v[0], Bank2: v[1]}
+}
+
+// DecodeIntakeMAPSensors will decode the intake manifold absolute pressure of sensors A and B, in kPa. res must be 5 bytes
+func DecodeIntakeMAPSensors(res []byte) SensorPair {
+	v := decodeSensors(res, 2, 2, func(raw uint32) float64 { return float64(raw) * 0.03125 })
+	return SensorPair{A: v[0], B: v[1]}
+}
+
+// FuelRate is the decoded result of PIDFuelRate
+type FuelRate struct {
+	// Engine is the engine fuel rate in grams/sec
+	Engine float64
+
+	// Vehicle is the vehicle fuel rate in grams/sec
+	Vehicle float64
+}
+
+// DecodeFuelRate will decode the response for PIDFuelRate. res must be 4 bytes
+func DecodeFuelRate(res []byte) FuelRate {
+	return FuelRate{
+		Engine:  float64(binary.BigEndian.Uint16(res)) * 0.02,
+		Vehicle: float64(binary.BigEndian.Uint16(res[2:])) * 0.02,
+	}
+}
+
+// DecodeExhaustFlowRate will return the engine exhaust flow rate in kg/h. res must be 2 bytes
+func DecodeExhaustFlowRate(res []byte) float64 {
+	return float64(binary.BigEndian.Uint16(res)) * 0.2
+}
+
+// DecodeCylinderFuelRate will return the cylinder fuel rate in mg/stroke. res must be 2 bytes
+func DecodeCylinderFuelRate(res []byte) float64 {
+	return float64(binary.BigEndian.Uint16(res)) / 32
+}
+
+// TransmissionGear is the decoded result of PIDTransmissionGear
+type TransmissionGear struct {
+	// Supported is set if the ECU reports the actual gear as supported
+	Supported bool
+
+	// Gear is the actual gear (0 is neutral, 15 is reverse)
+	Gear int
+
+	// Ratio is the actual gear ratio
+	Ratio float64
+}
+
+// DecodeTransmissionGear will decode the response for PIDTransmissionGear. res must be 4 bytes
+func DecodeTransmissionGear(res []byte) TransmissionGear {
+	return TransmissionGear{
+		Supported: res[0]&(1<<1) != 0,
+		Gear:      int(res[1] >> 4),
+		Ratio:     float64(binary.BigEndian.Uint16(res[2:])) / 1000,
+	}
+}
+
+// DecodeDEFDosing will decode the commanded diesel exhaust fluid dosing as a percentage (between 0 and 1). res must be 2 bytes
+func DecodeDEFDosing(res []byte) SensorValue {
+	return SensorValue{Supported: res[0]&1 != 0, Value: float64(res[1]) / 200}
+}
+
+// DecodeOdometer will return the odometer reading in km. res must be 4 bytes
+func DecodeOdometer(res []byte) float64 {
+	return float64(binary.BigEndian.Uint32(res)) / 10
+}
@@ -0,0 +1,132 @@
+// Package mode2 reads freeze frames (service 0x02): the mode 1 PID values an ECU stored when a trouble code was set
+package mode2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mastercactapus/obd2"
+	"github.com/mastercactapus/obd2/mode1"
+)
+
+// ID is the identifier to use for mode2 commands
+const ID byte = 0x02
+
+// FreezeFrame is the snapshot of PID data an ECU stored when a trouble code was set
+type FreezeFrame struct {
+	// Frame is the frame number. Most ECUs only store frame 0
+	Frame byte
+
+	// DTC is the trouble code that caused the frame to be stored
+	DTC obd2.DTC
+
+	// Supported is the set of PIDs stored in the frame
+	Supported mode1.PIDSet
+
+	// Data holds the raw data of each PID read from the frame, not including the PID and frame number
+	Data map[byte][]byte
+
+	// Values holds the value of each PID in Data, decoded with mode1.Decode. PIDs that can't be decoded are left out
+	Values map[byte]interface{}
+}
+
+// ReadPID will request pid from frame of every ECU, and return the data of each that responded. The data does not
+// include the PID and frame number. An empty map is returned if no ECU has the PID stored
+func ReadPID(ctx context.Context, c *obd2.Client, frame, pid byte) (map[obd2.Address][]byte, error) {
+	msgs, err := c.QueryAll(ctx, &obd2.Request{Mode: ID, Args: []byte{pid, frame}})
+	if errors.Is(err, obd2.ErrNoResponse) {
+		return map[obd2.Address][]byte{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[obd2.Address][]byte, len(msgs))
+	for _, m := range msgs {
+		if len(m.Data) < 2 || m.Data[0] != pid || m.Data[1] != frame {
+			continue
+		}
+		res[m.Source] = m.Data[2:]
+	}
+	return res, nil
+}
+
+// Read will read a freeze frame from every ECU that has one stored. It reads the DTC that caused it to be stored,
+// which PIDs it holds, and then the data of each of them
+func Read(ctx context.Context, c *obd2.Client, frame byte) (map[obd2.Address]*FreezeFrame, error) {
+	dtcs, err := ReadPID(ctx, c, frame, mode1.PIDFreeze)
+	if err != nil {
+		return nil, fmt.Errorf("read DTC: %w", err)
+	}
+	frames := make(map[obd2.Address]*FreezeFrame, len(dtcs))
+	for addr, data := range dtcs {
+		if len(data) < 2 || data[0] == 0 && data[1] == 0 {
+			// no frame stored
+			continue
+		}
+		frames[addr] = &FreezeFrame{
+			Frame:  frame,
+			DTC:    obd2.DecodeDTC(data),
+			Data:   map[byte][]byte{mode1.PIDFreeze: data},
+			Values: make(map[byte]interface{}),
+		}
+	}
+	if len(frames) == 0 {
+		return frames, nil
+	}
+
+	err = readSupported(ctx, c, frame, frames)
+	if err != nil {
+		return nil, err
+	}
+
+	var all mode1.PIDSet
+	for _, f := range frames {
+		all = all.Union(f.Supported)
+	}
+	for _, pid := range all.PIDs() {
+		if pid%0x20 == 0 || pid == mode1.PIDFreeze {
+			continue
+		}
+		res, err := ReadPID(ctx, c, frame, pid)
+		if err != nil {
+			return nil, fmt.Errorf("read PID 0x%02x: %w", pid, err)
+		}
+		for addr, data := range res {
+			f, ok := frames[addr]
+			if !ok {
+				continue
+			}
+			f.Data[pid] = data
+			if v, err := mode1.Decode(pid, data); err == nil {
+				f.Values[pid] = v
+			}
+		}
+	}
+	return frames, nil
+}
+
+// readSupported will walk the support PIDs of the frame and fill in the supported PIDs of each ECU in frames
+func readSupported(ctx context.Context, c *obd2.Client, frame byte, frames map[obd2.Address]*FreezeFrame) error {
+	for base := 0; base <= int(mode1.PIDSupport8); base += 0x20 {
+		res, err := ReadPID(ctx, c, frame, byte(base))
+		if err != nil {
+			return fmt.Errorf("read supported PIDs: %w", err)
+		}
+		more := false
+		for addr, data := range res {
+			f, ok := frames[addr]
+			if !ok || len(data) < 4 {
+				continue
+			}
+			f.Supported.AddSupported(byte(base), data[:4])
+			if f.Supported.Has(byte(base) + 0x20) {
+				more = true
+			}
+		}
+		if !more {
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package mode2
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/mastercactapus/obd2"
+	"github.com/mastercactapus/obd2/mode1"
+	"github.com/mastercactapus/obd2/sim"
+)
+
+// failing is a transport that always returns err
+type failing struct{ err error }
+
+func (f failing) RoundTrip(req *obd2.Request) (*obd2.Response, error) { return nil, f.err }
+
+func TestRead(t *testing.T) {
+	p0301 := obd2.DTC{Type: obd2.DTCTypePowertrain, Category: obd2.DTCCategorySAE, System: obd2.DTCSystemIgnition, Fault: 0x01}
+	p0700 := obd2.DTC{Type: obd2.DTCTypePowertrain, Category: obd2.DTCCategorySAE, System: obd2.DTCSystemTransimission1, Fault: 0x00}
+	rpm := mode1.EncodeEngineRPM(2500)
+	voltage := mode1.EncodeControlModuleVoltage(13.5)
+
+	// the engine ECU stores a PID past 0x20, so the support PIDs have to be walked
+	ecm := sim.NewECU(0x7e8)
+	ecm.FreezeFrames = []sim.FreezeFrame{{DTC: p0301, PIDs: map[byte][]byte{
+		mode1.PIDEngineRPM:            rpm,
+		mode1.PIDECT:                  {mode1.EncodeECT(90)},
+		mode1.PIDControlModuleVoltage: voltage,
+	}}}
+
+	// the transmission ECU stores a PID that is too short to decode
+	tcm := sim.NewECU(0x7e9)
+	tcm.FreezeFrames = []sim.FreezeFrame{{DTC: p0700, PIDs: map[byte][]byte{
+		mode1.PIDVehicleSpeed: {60},
+		mode1.PIDEngineRPM:    {0x27},
+	}}}
+
+	// a frame with DTC 0000 holds no data
+	abs := sim.NewECU(0x7ea)
+	abs.FreezeFrames = []sim.FreezeFrame{{PIDs: map[byte][]byte{mode1.PIDVehicleSpeed: {60}}}}
+
+	frames, err := Read(context.Background(), obd2.NewClient(sim.NewVehicle(ecm, tcm, abs)), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		addr      obd2.Address
+		dtc       obd2.DTC
+		supported []byte
+		data      map[byte][]byte
+		values    map[byte]interface{}
+	}{
+		{
+			addr:      0x7e8,
+			dtc:       p0301,
+			supported: []byte{mode1.PIDFreeze, mode1.PIDECT, mode1.PIDEngineRPM, mode1.PIDSupport2, mode1.PIDSupport3, mode1.PIDControlModuleVoltage},
+			data: map[byte][]byte{
+				mode1.PIDFreeze:               obd2.EncodeDTC(p0301),
+				mode1.PIDECT:                  {mode1.EncodeECT(90)},
+				mode1.PIDEngineRPM:            rpm,
+				mode1.PIDControlModuleVoltage: voltage,
+			},
+			values: map[byte]interface{}{
+				mode1.PIDECT:                  90,
+				mode1.PIDEngineRPM:            2500.0,
+				mode1.PIDControlModuleVoltage: mode1.DecodeControlModuleVoltage(voltage),
+			},
+		},
+		{
+			addr:      0x7e9,
+			dtc:       p0700,
+			supported: []byte{mode1.PIDFreeze, mode1.PIDEngineRPM, mode1.PIDVehicleSpeed},
+			data: map[byte][]byte{
+				mode1.PIDFreeze:       obd2.EncodeDTC(p0700),
+				mode1.PIDEngineRPM:    {0x27},
+				mode1.PIDVehicleSpeed: {60},
+			},
+			values: map[byte]interface{}{
+				mode1.PIDVehicleSpeed: 60,
+			},
+		},
+	}
+	if len(frames) != len(cases) {
+		t.Errorf("got frames from %d ECUs; want %d", len(frames), len(cases))
+	}
+	for _, c := range cases {
+		f, ok := frames[c.addr]
+		if !ok {
+			t.Errorf("ECU %X: no frame", c.addr)
+			continue
+		}
+		if f.DTC != c.dtc {
+			t.Errorf("ECU %X: DTC %s; want %s", c.addr, f.DTC, c.dtc)
+		}
+		if got := f.Supported.PIDs(); !reflect.DeepEqual(got, c.supported) {
+			t.Errorf("ECU %X: supported % x; want % x", c.addr, got, c.supported)
+		}
+		if !reflect.DeepEqual(f.Data, c.data) {
+			t.Errorf("ECU %X: data %x; want %x", c.addr, f.Data, c.data)
+		}
+		if !reflect.DeepEqual(f.Values, c.values) {
+			t.Errorf("ECU %X: values %v; want %v", c.addr, f.Values, c.values)
+		}
+	}
+}
+
+func TestReadPIDNoResponse(t *testing.T) {
+	for _, err := range []error{obd2.ErrNoResponse, fmt.Errorf("replayed: %w", obd2.ErrNoResponse)} {
+		res, rerr := ReadPID(context.Background(), obd2.NewClient(failing{err}), 0, mode1.PIDEngineRPM)
+		if rerr != nil || len(res) != 0 {
+			t.Errorf("%v: got %v, %v; want an empty map", err, res, rerr)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package mode3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mastercactapus/obd2"
+)
+
+// Kind is the kind of a trouble code. The value is the mode used to read it
+type Kind byte
+
+// Kinds of trouble codes
+const (
+	// Stored codes are confirmed faults, and usually turn on the MIL
+	Stored Kind = 0x03
+
+	// Pending codes were detected during the current or last drive cycle, but are not yet confirmed
+	Pending Kind = 0x07
+
+	// Permanent codes can not be cleared by mode 4. They are cleared by the ECU once it verifies the fault is gone
+	Permanent Kind = 0x0a
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Stored:
+		return "Stored"
+	case Pending:
+		return "Pending"
+	case Permanent:
+		return "Permanent"
+	}
+	return fmt.Sprintf("Kind(0x%02x)", byte(k))
+}
+
+// Code is a trouble code tagged with its kind
+type Code struct {
+	obd2.DTC
+	Kind Kind
+}
+
+func (c Code) String() string {
+	return c.DTC.String() + " (" + c.Kind.String() + ")"
+}
+
+// ReadAll will read each kind of trouble code (all kinds if none are given) and return the codes of every ECU that
+// responded to any of them. An ECU that does not support a kind (such as Permanent, on older vehicles) is skipped for that kind
+func ReadAll(ctx context.Context, c *obd2.Client, kinds ...Kind) (map[obd2.Address][]Code, error) {
+	if len(kinds) == 0 {
+		kinds = []Kind{Stored, Pending, Permanent}
+	}
+	codes := make(map[obd2.Address][]Code)
+	for _, k := range kinds {
+		res, err := Query(ctx, c, byte(k))
+		if err != nil {
+			var neg obd2.NegativeResponse
+			if errors.As(err, &neg) || errors.Is(err, obd2.ErrNoResponse) {
+				continue
+			}
+			return nil, fmt.Errorf("read %s codes: %w", k, err)
+		}
+		for addr, dtcs := range res {
+			list := codes[addr]
+			for _, d := range dtcs {
+				list = append(list, Code{DTC: d, Kind: k})
+			}
+			codes[addr] = list
+		}
+	}
+	return codes, nil
+}
@@ -0,0 +1,63 @@
+// Package mode3 reads the emissions-related trouble codes stored by each ECU (service 0x03). It also decodes the
+// pending (mode 7) and permanent (mode 0A) codes, which share the same format
+package mode3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mastercactapus/obd2"
+)
+
+// ID is the identifier to use for mode3 commands
+const ID byte = 0x03
+
+// ErrShortResponse is returned when a response holds fewer trouble codes than its count byte reports
+var ErrShortResponse = errors.New("response shorter than DTC count")
+
+// DecodeDTCs will decode the trouble codes of a single response (not including the mode byte). An odd length
+// means the response is from a CAN ECU and starts with a count byte. Otherwise the codes are in 2 byte pairs, as sent by
+// legacy protocols, where unused pairs are 0x0000 padding and are skipped.
+// The same format is used by modes 7 and 0A
+func DecodeDTCs(data []byte) ([]obd2.DTC, error) {
+	if len(data)%2 == 1 {
+		n := int(data[0])
+		data = data[1:]
+		if len(data) < n*2 {
+			return nil, fmt.Errorf("%w: expected %d codes but got %d bytes", ErrShortResponse, n, len(data))
+		}
+		data = data[:n*2]
+	}
+	var codes []obd2.DTC
+	for i := 0; i+1 < len(data); i += 2 {
+		if data[i] == 0 && data[i+1] == 0 {
+			continue
+		}
+		codes = append(codes, obd2.DecodeDTC(data[i:]))
+	}
+	return codes, nil
+}
+
+// Query will send mode to every ECU and decode the trouble codes of each response. Every ECU that responded
+// has an entry, even if it reported no codes. Legacy ECUs may send several responses, which are combined
+func Query(ctx context.Context, c *obd2.Client, mode byte) (map[obd2.Address][]obd2.DTC, error) {
+	msgs, err := c.QueryAll(ctx, &obd2.Request{Mode: mode})
+	if err != nil {
+		return nil, err
+	}
+	codes := make(map[obd2.Address][]obd2.DTC, len(msgs))
+	for _, m := range msgs {
+		dtcs, err := DecodeDTCs(m.Data)
+		if err != nil {
+			return nil, fmt.Errorf("ECU %X: %w", m.Source, err)
+		}
+		codes[m.Source] = append(codes[m.Source], dtcs...)
+	}
+	return codes, nil
+}
+
+// Read will return the stored trouble codes of every ECU that responded
+func Read(ctx context.Context, c *obd2.Client) (map[obd2.Address][]obd2.DTC, error) {
+	return Query(ctx, c, ID)
+}
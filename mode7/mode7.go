@@ -0,0 +1,18 @@
+// Package mode7 reads the pending trouble codes of each ECU (service 0x07): faults detected during the current or
+// last drive cycle that are not yet confirmed
+package mode7
+
+import (
+	"context"
+
+	"github.com/mastercactapus/obd2"
+	"github.com/mastercactapus/obd2/mode3"
+)
+
+// ID is the identifier to use for mode7 commands
+const ID = byte(mode3.Pending)
+
+// Read will return the pending trouble codes of every ECU that responded
+func Read(ctx context.Context, c *obd2.Client) (map[obd2.Address][]obd2.DTC, error) {
+	return mode3.Query(ctx, c, ID)
+}
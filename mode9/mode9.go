@@ -0,0 +1,461 @@
+// Package mode9 reads vehicle information (service 0x09), such as the VIN and the calibration of each ECU
+package mode9
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/mastercactapus/obd2"
+	"github.com/mastercactapus/obd2/mode1"
+)
+
+// ID is the identifier to use for mode9 commands
+const ID byte = 0x09
+
+// Info types. The count info types are only needed by legacy (non-CAN) vehicles, and are handled by the readers
+const (
+	// InfoSupport1 will return supported info types from 0x01 to 0x20
+	InfoSupport1 byte = 0x00
+
+	// InfoVINCount is the number of messages used to send the VIN (legacy only)
+	InfoVINCount byte = 0x01
+
+	// InfoVIN is the 17 character vehicle identification number. Use with VIN
+	InfoVIN byte = 0x02
+
+	// InfoCALIDCount is the number of messages used to send the calibration IDs (legacy only)
+	InfoCALIDCount byte = 0x03
+
+	// InfoCALID is the calibration IDs, 16 characters each. Use with CalibrationIDs
+	InfoCALID byte = 0x04
+
+	// InfoCVNCount is the number of messages used to send the calibration verification numbers (legacy only)
+	InfoCVNCount byte = 0x05
+
+	// InfoCVN is the calibration verification numbers, 4 bytes each. Use with CVNs
+	InfoCVN byte = 0x06
+
+	// InfoPerfTrackingCount is the number of messages used to send the in-use performance tracking (legacy only)
+	InfoPerfTrackingCount byte = 0x07
+
+	// InfoPerfTrackingSpark is the in-use performance tracking for spark-ignition vehicles. Response is not decoded
+	InfoPerfTrackingSpark byte = 0x08
+
+	// InfoECUNameCount is the number of messages used to send the ECU name (legacy only)
+	InfoECUNameCount byte = 0x09
+
+	// InfoECUName is the 20 character name of the ECU. Use with ECUName
+	InfoECUName byte = 0x0a
+
+	// InfoPerfTrackingCompression is the in-use performance tracking for compression-ignition vehicles. Response is not decoded
+	InfoPerfTrackingCompression byte = 0x0b
+
+	// InfoESN is the 17 character engine serial number. Use with ESN
+	InfoESN byte = 0x0d
+
+	// InfoEROTAN is the 17 character exhaust regulation or type approval number. Use with EROTAN
+	InfoEROTAN byte = 0x0f
+)
+
+// ErrIncomplete is returned when an ECU sent fewer items, or legacy messages, than it reported
+var ErrIncomplete = errors.New("incomplete vehicle information")
+
+// ReadInfo will request an info type from every ECU, and return the data items of each that responded.
+// size is the length of each item. For CAN, each message holds a count of items followed by the items. For legacy
+// protocols, the data is spread over several numbered messages of 4 bytes each, which are put back in order.
+// An empty map is returned if no ECU supports the info type. The support info types have no item count, use Supported for them
+func ReadInfo(ctx context.Context, c *obd2.Client, info byte, size int) (map[obd2.Address][][]byte, error) {
+	msgs, err := c.QueryAll(ctx, &obd2.Request{Mode: ID, Args: []byte{info}})
+	if errors.Is(err, obd2.ErrNoResponse) {
+		return map[obd2.Address][][]byte{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var replies []obd2.Message
+	for _, m := range msgs {
+		if len(m.Data) >= 2 && m.Data[0] == info {
+			replies = append(replies, m)
+		}
+	}
+	if isLegacy(replies, size) {
+		return readLegacy(ctx, c, info, size, replies)
+	}
+
+	res := make(map[obd2.Address][][]byte, len(replies))
+	for _, m := range replies {
+		n := int(m.Data[1])
+		data := m.Data[2:]
+		if len(data) < n*size {
+			return nil, fmt.Errorf("%w: ECU %X info 0x%02x has %d bytes, expected %d items of %d", ErrIncomplete, m.Source, info, len(data), n, size)
+		}
+		if n == 0 {
+			continue
+		}
+		res[m.Source] = splitItems(data[:n*size], size)
+	}
+	return res, nil
+}
+
+// legacyMessageLen is the length of a legacy message: the info type, the message number and 4 bytes of data
+const legacyMessageLen = 6
+
+// isLegacy will return true if replies are numbered legacy messages. A CAN message can only be as short as a legacy
+// one if it holds a single item of 4 bytes, in which case both formats decode the same unless a message number is above 1
+func isLegacy(replies []obd2.Message, size int) bool {
+	if len(replies) == 0 {
+		return false
+	}
+	numbered := size != 4
+	for _, m := range replies {
+		if len(m.Data) != legacyMessageLen {
+			return false
+		}
+		if m.Data[1] > 1 {
+			numbered = true
+		}
+	}
+	return numbered
+}
+
+// countInfo will return the info type that reports the number of legacy messages used to send info, if it has one
+func countInfo(info byte) (byte, bool) {
+	if info < InfoVIN || info > InfoECUName || info%2 != 0 {
+		return 0, false
+	}
+	return info - 1, true
+}
+
+// readCounts will return the number of legacy messages each ECU reported for info. It returns nil if info has no
+// count info type, or no ECU reports it
+func readCounts(ctx context.Context, c *obd2.Client, info byte) (map[obd2.Address]int, error) {
+	count, ok := countInfo(info)
+	if !ok {
+		return nil, nil
+	}
+	msgs, err := c.QueryAll(ctx, &obd2.Request{Mode: ID, Args: []byte{count}})
+	var neg obd2.NegativeResponse
+	if errors.Is(err, obd2.ErrNoResponse) || errors.As(err, &neg) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read message count: %w", err)
+	}
+	counts := make(map[obd2.Address]int, len(msgs))
+	for _, m := range msgs {
+		if len(m.Data) >= 2 && m.Data[0] == count {
+			// the count is the last byte, whether or not the ECU sends a message number before it
+			counts[m.Source] = int(m.Data[len(m.Data)-1])
+		}
+	}
+	return counts, nil
+}
+
+// readLegacy will put the numbered legacy messages of each ECU back in order, and split them into items of size
+func readLegacy(ctx context.Context, c *obd2.Client, info byte, size int, replies []obd2.Message) (map[obd2.Address][][]byte, error) {
+	counts, err := readCounts(ctx, c, info)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[obd2.Address][][]byte)
+	for _, g := range groupLegacy(replies) {
+		want := len(g.parts)
+		if n, ok := counts[g.source]; ok && n > want {
+			want = n
+		}
+		sort.Slice(g.parts, func(i, j int) bool { return g.parts[i][0] < g.parts[j][0] })
+		var data []byte
+		for i := 0; i < want; i++ {
+			if i >= len(g.parts) || int(g.parts[i][0]) != i+1 {
+				return nil, fmt.Errorf("%w: ECU %X info 0x%02x is missing message %d of %d", ErrIncomplete, g.source, info, i+1, want)
+			}
+			data = append(data, g.parts[i][1:]...)
+		}
+		// legacy VIN, ESN and EROTAN are padded at the start to fill the last message
+		if pad := len(data) % size; pad > 0 && bytes.Count(data[:pad], []byte{0}) == pad {
+			data = data[pad:]
+		}
+		if len(data) < size {
+			return nil, fmt.Errorf("%w: ECU %X info 0x%02x has %d bytes, expected at least %d", ErrIncomplete, g.source, info, len(data), size)
+		}
+		res[g.source] = splitItems(data, size)
+	}
+	return res, nil
+}
+
+// legacyGroup is the numbered messages (without the info type) sent by one ECU
+type legacyGroup struct {
+	source obd2.Address
+	parts  [][]byte
+}
+
+// groupLegacy will group replies by the ECU that sent them. If the transport can't tell ECUs apart, every message
+// has its own source (see obd2.Message) even when an ECU sent several. The n-th message with a given number is then
+// taken to be from the n-th ECU, and the groups are numbered from zero in the same way as the count replies
+func groupLegacy(replies []obd2.Message) []legacyGroup {
+	bySource := make(map[obd2.Address]int)
+	var groups []legacyGroup
+	numbered := false
+	for _, m := range replies {
+		numbered = numbered || m.Data[1] > 1
+		i, ok := bySource[m.Source]
+		if !ok {
+			i = len(groups)
+			bySource[m.Source] = i
+			groups = append(groups, legacyGroup{source: m.Source})
+		}
+		groups[i].parts = append(groups[i].parts, m.Data[1:])
+	}
+	if len(groups) < len(replies) || !numbered {
+		return groups
+	}
+
+	groups = groups[:0]
+	seen := make(map[byte]int)
+	for _, m := range replies {
+		i := seen[m.Data[1]]
+		seen[m.Data[1]]++
+		for len(groups) <= i {
+			groups = append(groups, legacyGroup{source: obd2.Address(len(groups))})
+		}
+		groups[i].parts = append(groups[i].parts, m.Data[1:])
+	}
+	return groups
+}
+
+// splitItems will split data into items of size, ignoring any bytes left over
+func splitItems(data []byte, size int) [][]byte {
+	var items [][]byte
+	for len(data) >= size {
+		items = append(items, data[:size])
+		data = data[size:]
+	}
+	return items
+}
+
+// text will return data as a string, without the null bytes used for padding
+func text(data []byte) string {
+	return string(bytes.Trim(data, "\x00"))
+}
+
+// readText will read a single text item of size from each ECU
+func readText(ctx context.Context, c *obd2.Client, info byte, size int) (map[obd2.Address]string, error) {
+	items, err := ReadInfo(ctx, c, info, size)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[obd2.Address]string, len(items))
+	for addr, it := range items {
+		res[addr] = text(it[0])
+	}
+	return res, nil
+}
+
+// Supported will walk the support info types (0x00, 0x20, ...) and return the info types supported by each ECU that responded
+func Supported(ctx context.Context, c *obd2.Client) (map[obd2.Address]mode1.PIDSet, error) {
+	sets := make(map[obd2.Address]mode1.PIDSet)
+	for base := 0; base <= 0xe0; base += 0x20 {
+		msgs, err := c.QueryAll(ctx, &obd2.Request{Mode: ID, Args: []byte{byte(base)}})
+		if errors.Is(err, obd2.ErrNoResponse) && base > 0 {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		more := false
+		for _, m := range msgs {
+			// CAN sends only the bitmap, legacy protocols send a message number first
+			if len(m.Data) < 5 || m.Data[0] != byte(base) {
+				continue
+			}
+			set := sets[m.Source]
+			set.AddSupported(byte(base), m.Data[len(m.Data)-4:])
+			sets[m.Source] = set
+			if set.Has(byte(base) + 0x20) {
+				more = true
+			}
+		}
+		if !more {
+			break
+		}
+	}
+	if len(sets) == 0 {
+		return nil, obd2.ErrNoResponse
+	}
+	return sets, nil
+}
+
+// VIN will return the vehicle identification number reported by each ECU. Usually only the engine ECU reports it
+func VIN(ctx context.Context, c *obd2.Client) (map[obd2.Address]string, error) {
+	return readText(ctx, c, InfoVIN, 17)
+}
+
+// CalibrationIDs will return the calibration IDs of each ECU
+func CalibrationIDs(ctx context.Context, c *obd2.Client) (map[obd2.Address][]string, error) {
+	items, err := ReadInfo(ctx, c, InfoCALID, 16)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[obd2.Address][]string, len(items))
+	for addr, it := range items {
+		ids := make([]string, len(it))
+		for i, id := range it {
+			ids[i] = text(id)
+		}
+		res[addr] = ids
+	}
+	return res, nil
+}
+
+// CVNs will return the calibration verification numbers of each ECU, in the same order as the calibration IDs.
+// They are normally displayed as 8 hex digits
+func CVNs(ctx context.Context, c *obd2.Client) (map[obd2.Address][]uint32, error) {
+	items, err := ReadInfo(ctx, c, InfoCVN, 4)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[obd2.Address][]uint32, len(items))
+	for addr, it := range items {
+		cvns := make([]uint32, len(it))
+		for i, cvn := range it {
+			cvns[i] = binary.BigEndian.Uint32(cvn)
+		}
+		res[addr] = cvns
+	}
+	return res, nil
+}
+
+// ECUName is the name of an ECU
+type ECUName struct {
+	// Acronym is the short name (e.g. "ECM")
+	Acronym string
+
+	// Name is the full name (e.g. "EngineControl")
+	Name string
+}
+
+func (n ECUName) String() string {
+	return n.Acronym + "-" + n.Name
+}
+
+// ECUNames will return the name of each ECU
+func ECUNames(ctx context.Context, c *obd2.Client) (map[obd2.Address]ECUName, error) {
+	items, err := ReadInfo(ctx, c, InfoECUName, 20)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[obd2.Address]ECUName, len(items))
+	for addr, it := range items {
+		// 4 characters of acronym, a dash, and 15 of name
+		res[addr] = ECUName{Acronym: text(it[0][:4]), Name: text(it[0][5:])}
+	}
+	return res, nil
+}
+
+// ESN will return the engine serial number reported by each ECU
+func ESN(ctx context.Context, c *obd2.Client) (map[obd2.Address]string, error) {
+	return readText(ctx, c, InfoESN, 17)
+}
+
+// EROTAN will return the exhaust regulation or type approval number reported by each ECU
+func EROTAN(ctx context.Context, c *obd2.Client) (map[obd2.Address]string, error) {
+	return readText(ctx, c, InfoEROTAN, 17)
+}
+
+// VehicleInfo is the information reported by a single ECU. Fields are empty if the ECU does not support them
+type VehicleInfo struct {
+	Supported      mode1.PIDSet
+	VIN            string
+	CalibrationIDs []string
+	CVNs           []uint32
+	Name           ECUName
+	ESN            string
+	EROTAN         string
+}
+
+// Read will read every supported info type from each ECU that responded
+func Read(ctx context.Context, c *obd2.Client) (map[obd2.Address]*VehicleInfo, error) {
+	sets, err := Supported(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	infos := make(map[obd2.Address]*VehicleInfo, len(sets))
+	var all mode1.PIDSet
+	for addr, set := range sets {
+		infos[addr] = &VehicleInfo{Supported: set}
+		all = all.Union(set)
+	}
+
+	if all.Has(InfoVIN) {
+		res, err := VIN(ctx, c)
+		if err != nil {
+			return nil, fmt.Errorf("read VIN: %w", err)
+		}
+		for addr, v := range res {
+			if i, ok := infos[addr]; ok {
+				i.VIN = v
+			}
+		}
+	}
+	if all.Has(InfoCALID) {
+		res, err := CalibrationIDs(ctx, c)
+		if err != nil {
+			return nil, fmt.Errorf("read calibration IDs: %w", err)
+		}
+		for addr, v := range res {
+			if i, ok := infos[addr]; ok {
+				i.CalibrationIDs = v
+			}
+		}
+	}
+	if all.Has(InfoCVN) {
+		res, err := CVNs(ctx, c)
+		if err != nil {
+			return nil, fmt.Errorf("read CVNs: %w", err)
+		}
+		for addr, v := range res {
+			if i, ok := infos[addr]; ok {
+				i.CVNs = v
+			}
+		}
+	}
+	if all.Has(InfoECUName) {
+		res, err := ECUNames(ctx, c)
+		if err != nil {
+			return nil, fmt.Errorf("read ECU name: %w", err)
+		}
+		for addr, v := range res {
+			if i, ok := infos[addr]; ok {
+				i.Name = v
+			}
+		}
+	}
+	if all.Has(InfoESN) {
+		res, err := ESN(ctx, c)
+		if err != nil {
+			return nil, fmt.Errorf("read ESN: %w", err)
+		}
+		for addr, v := range res {
+			if i, ok := infos[addr]; ok {
+				i.ESN = v
+			}
+		}
+	}
+	if all.Has(InfoEROTAN) {
+		res, err := EROTAN(ctx, c)
+		if err != nil {
+			return nil, fmt.Errorf("read EROTAN: %w", err)
+		}
+		for addr, v := range res {
+			if i, ok := infos[addr]; ok {
+				i.EROTAN = v
+			}
+		}
+	}
+	return infos, nil
+}
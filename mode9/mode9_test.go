@@ -0,0 +1,172 @@
+package mode9
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/mastercactapus/obd2"
+)
+
+// replies is a transport that answers each info type with fixed messages (without the response mode byte)
+type replies map[byte][]obd2.Message
+
+func (r replies) RoundTrip(req *obd2.Request) (*obd2.Response, error) {
+	res := &obd2.Response{}
+	for _, m := range r[req.Args[0]] {
+		res.Messages = append(res.Messages, obd2.Message{Source: m.Source, Data: append([]byte{0x49}, m.Data...)})
+	}
+	return res, nil
+}
+
+const testVIN = "1G1JC5444R7252367"
+
+// canText will return a CAN reply with a single text item
+func canText(src obd2.Address, info byte, s string) obd2.Message {
+	return obd2.Message{Source: src, Data: append([]byte{info, 1}, s...)}
+}
+
+// legacyText will return the numbered legacy messages for s, padded at the start with null bytes
+func legacyText(src obd2.Address, info byte, s string) []obd2.Message {
+	data := []byte(s)
+	for len(data)%4 != 0 {
+		data = append([]byte{0}, data...)
+	}
+	var msgs []obd2.Message
+	for i := 0; i < len(data); i += 4 {
+		msgs = append(msgs, obd2.Message{Source: src, Data: append([]byte{info, byte(i/4 + 1)}, data[i:i+4]...)})
+	}
+	return msgs
+}
+
+// renumber will set the source of each message to its position, as a transport without headers does
+func renumber(msgs []obd2.Message) []obd2.Message {
+	res := make([]obd2.Message, len(msgs))
+	for i, m := range msgs {
+		res[i] = obd2.Message{Source: obd2.Address(i), Data: m.Data}
+	}
+	return res
+}
+
+func TestVIN(t *testing.T) {
+	ecm := legacyText(0x10, InfoVIN, testVIN)
+	tcm := legacyText(0x18, InfoVIN, "2G1JC5444R7252368")
+	var interleaved []obd2.Message
+	for i := range ecm {
+		interleaved = append(interleaved, ecm[i], tcm[i])
+	}
+	counts := []obd2.Message{{Source: 0x10, Data: []byte{InfoVINCount, 5}}, {Source: 0x18, Data: []byte{InfoVINCount, 5}}}
+
+	cases := []struct {
+		name string
+		r    replies
+		want map[obd2.Address]string
+	}{
+		{
+			name: "CAN",
+			r:    replies{InfoVIN: {canText(0x7e8, InfoVIN, testVIN), canText(0x7e9, InfoVIN, "2G1JC5444R7252368")}},
+			want: map[obd2.Address]string{0x7e8: testVIN, 0x7e9: "2G1JC5444R7252368"},
+		},
+		{
+			name: "CAN/NoHeaders",
+			r:    replies{InfoVIN: renumber([]obd2.Message{canText(0, InfoVIN, testVIN), canText(0, InfoVIN, "2G1JC5444R7252368")})},
+			want: map[obd2.Address]string{0: testVIN, 1: "2G1JC5444R7252368"},
+		},
+		{
+			name: "Legacy",
+			r:    replies{InfoVIN: interleaved, InfoVINCount: counts},
+			want: map[obd2.Address]string{0x10: testVIN, 0x18: "2G1JC5444R7252368"},
+		},
+		{
+			name: "Legacy/NoCount",
+			r:    replies{InfoVIN: ecm},
+			want: map[obd2.Address]string{0x10: testVIN},
+		},
+		{
+			name: "Legacy/NoHeaders",
+			r:    replies{InfoVIN: renumber(append(append([]obd2.Message{}, ecm...), tcm...)), InfoVINCount: renumber(counts)},
+			want: map[obd2.Address]string{0: testVIN, 1: "2G1JC5444R7252368"},
+		},
+		{
+			name: "Legacy/NoHeadersInterleaved",
+			r:    replies{InfoVIN: renumber(interleaved), InfoVINCount: renumber(counts)},
+			want: map[obd2.Address]string{0: testVIN, 1: "2G1JC5444R7252368"},
+		},
+	}
+	for _, c := range cases {
+		got, err := VIN(context.Background(), obd2.NewClient(c.r))
+		if err != nil {
+			t.Errorf("%s: %v", c.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: got %v; want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestCVNs(t *testing.T) {
+	cvn := func(src obd2.Address, n byte, v byte) obd2.Message {
+		return obd2.Message{Source: src, Data: []byte{InfoCVN, n, 0, 0, 0, v}}
+	}
+	cases := []struct {
+		name string
+		r    replies
+		want map[obd2.Address][]uint32
+	}{
+		{
+			name: "CAN",
+			r: replies{InfoCVN: {
+				{Source: 0x7e8, Data: []byte{InfoCVN, 2, 0, 0, 0, 1, 0, 0, 0, 2}},
+				cvn(0x7e9, 1, 7),
+			}},
+			want: map[obd2.Address][]uint32{0x7e8: {1, 2}, 0x7e9: {7}},
+		},
+		{
+			name: "Legacy",
+			r: replies{
+				InfoCVN:      {cvn(0x10, 1, 1), cvn(0x18, 1, 7), cvn(0x10, 2, 2)},
+				InfoCVNCount: {{Source: 0x10, Data: []byte{InfoCVNCount, 2}}, {Source: 0x18, Data: []byte{InfoCVNCount, 1}}},
+			},
+			want: map[obd2.Address][]uint32{0x10: {1, 2}, 0x18: {7}},
+		},
+	}
+	for _, c := range cases {
+		got, err := CVNs(context.Background(), obd2.NewClient(c.r))
+		if err != nil {
+			t.Errorf("%s: %v", c.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: got %v; want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestReadInfoIncomplete(t *testing.T) {
+	ecm := legacyText(0x10, InfoVIN, testVIN)
+	cases := []struct {
+		name string
+		r    replies
+	}{
+		{
+			name: "CAN",
+			r:    replies{InfoVIN: {{Source: 0x7e8, Data: append([]byte{InfoVIN, 1}, testVIN[:16]...)}}},
+		},
+		{
+			name: "Legacy/Middle",
+			r:    replies{InfoVIN: append(append([]obd2.Message{}, ecm[:2]...), ecm[3:]...)},
+		},
+		{
+			name: "Legacy/Last",
+			r:    replies{InfoVIN: ecm[:4], InfoVINCount: {{Source: 0x10, Data: []byte{InfoVINCount, 5}}}},
+		},
+	}
+	for _, c := range cases {
+		_, err := VIN(context.Background(), obd2.NewClient(c.r))
+		if !errors.Is(err, ErrIncomplete) {
+			t.Errorf("%s: got %v; want ErrIncomplete", c.name, err)
+		}
+	}
+}
@@ -1,15 +1,95 @@
 package obd2
 
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrNoResponse is returned when a request gets no usable response
+var ErrNoResponse = errors.New("no response")
+
+// Address identifies an ECU. For CAN it is the 11 or 29 bit identifier, for older protocols it is the header
+// (when sending) or the source address (when receiving)
+type Address uint32
+
+const (
+	// AddressFunctional will broadcast a request to all ECUs (0x7DF for 11 bit CAN)
+	AddressFunctional Address = 0
+
+	// AddressECM is the physical request address of the engine control module for 11 bit CAN.
+	// Up to 8 ECUs can be addressed from 0x7E0 to 0x7E7
+	AddressECM Address = 0x7e0
+
+	// AddressTCM is the physical request address of the transmission control module for 11 bit CAN
+	AddressTCM Address = 0x7e1
+)
+
 type Request struct {
 	Mode byte
 	Args []byte
+
+	// Address is the ECU to send the request to. If zero (AddressFunctional) the request is sent to all ECUs
+	Address Address
+}
+
+// Response contains a message from every ECU that responded to a request
+type Response struct {
+	Messages []Message
+}
+
+// Message is the response of a single ECU
+type Message struct {
+	// Source is the address of the ECU that sent the message. If the transport can't tell (e.g. ELM327 with
+	// headers off), messages are numbered from zero in the order they were received instead. Those numbers
+	// keep the messages of one response apart, but may refer to a different ECU in the next response
+	Source Address
+
+	// Data is the message payload, starting with the response mode
+	Data []byte
+}
+
+// Payload will validate the message as a response to a request of the given mode, and return the data
+// following the response mode byte. If the ECU rejected the request, a NegativeResponse is returned
+func (m Message) Payload(mode byte) ([]byte, error) {
+	if len(m.Data) == 0 {
+		return nil, ErrNoResponse
+	}
+	if m.Data[0] == 0x7f {
+		if len(m.Data) < 3 || m.Data[1] != mode {
+			return nil, fmt.Errorf("malformed negative response: % x", m.Data)
+		}
+		return nil, NegativeResponse{Mode: m.Data[1], Code: m.Data[2]}
+	}
+	if m.Data[0] != mode+0x40 {
+		return nil, fmt.Errorf("unexpected response mode 0x%02x for request mode 0x%02x", m.Data[0], mode)
+	}
+	return m.Data[1:], nil
 }
-type Response []byte
 
 type Transport interface {
 	RoundTrip(req *Request) (*Response, error)
 }
 
+// ContextTransport is a Transport that can abandon a request when a context is cancelled or its deadline expires
+type ContextTransport interface {
+	Transport
+	RoundTripContext(ctx context.Context, req *Request) (*Response, error)
+}
+
+// NegativeResponse is returned when an ECU rejects a request
+type NegativeResponse struct {
+	// Mode is the mode of the rejected request
+	Mode byte
+
+	// Code is the reason the request was rejected
+	Code byte
+}
+
+func (n NegativeResponse) Error() string {
+	return fmt.Sprintf("negative response to mode 0x%02x: code 0x%02x", n.Mode, n.Code)
+}
+
 type Client struct {
 	t Transport
 }
@@ -17,3 +97,54 @@ type Client struct {
 func NewClient(t Transport) *Client {
 	return &Client{t: t}
 }
+
+// roundTrip will send the request using the context if the transport supports it. Otherwise
+// ctx is only checked before the request is sent
+func (c *Client) roundTrip(ctx context.Context, req *Request) (*Response, error) {
+	if t, ok := c.t.(ContextTransport); ok {
+		return t.RoundTripContext(ctx, req)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.t.RoundTrip(req)
+}
+
+// Query will send a request and return the data from the first ECU to respond. The returned data does not include
+// the response mode byte
+func (c *Client) Query(ctx context.Context, req *Request) ([]byte, error) {
+	msgs, err := c.QueryAll(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return msgs[0].Data, nil
+}
+
+// QueryAll will send a request and return the message from each ECU that responded. The Data of each returned
+// message does not include the response mode byte. Messages that fail validation are dropped, and the first
+// validation error is returned if there are none left
+func (c *Client) QueryAll(ctx context.Context, req *Request) ([]Message, error) {
+	res, err := c.roundTrip(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil || len(res.Messages) == 0 {
+		return nil, ErrNoResponse
+	}
+	msgs := make([]Message, 0, len(res.Messages))
+	var firstErr error
+	for _, m := range res.Messages {
+		data, err := m.Payload(req.Mode)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		msgs = append(msgs, Message{Source: m.Source, Data: data})
+	}
+	if len(msgs) == 0 {
+		return nil, firstErr
+	}
+	return msgs, nil
+}
@@ -0,0 +1,156 @@
+package sim
+
+import (
+	"time"
+
+	"github.com/mastercactapus/obd2"
+	"github.com/mastercactapus/obd2/mode1"
+)
+
+// ECU is a simulated electronic control unit
+type ECU struct {
+	// Address is the address the ECU responds from (e.g. 0x7E8). For 11 bit CAN, the ECU accepts physical
+	// requests 8 below its address. For 29 bit CAN, the target and source bytes are swapped
+	Address obd2.Address
+
+	// PIDs holds the mode 1 value of every supported PID. The support PIDs (0x00, 0x20, ...) are generated
+	// and should not be set
+	PIDs map[byte]Value
+
+	// Stored holds the DTCs returned by mode 3
+	Stored []obd2.DTC
+
+	// Pending holds the DTCs returned by mode 7
+	Pending []obd2.DTC
+
+	// Permanent holds the DTCs returned by mode 0A. They are not cleared by mode 4
+	Permanent []obd2.DTC
+
+	// FreezeFrames holds the frames returned by mode 2, by frame number
+	FreezeFrames []FreezeFrame
+
+	// VIN is returned by mode 9 if set
+	VIN string
+
+	// CalibrationIDs and CVNs are returned by mode 9 if set. There should be a CVN for each calibration ID
+	CalibrationIDs []string
+	CVNs           []uint32
+
+	// Name is the ECU name returned by mode 9 if set, as an acronym and name separated by a dash (e.g. "ECM-EngineControl")
+	Name string
+}
+
+// FreezeFrame is the snapshot of PID data taken when a DTC was set
+type FreezeFrame struct {
+	// DTC is the trouble code that caused the freeze frame to be stored
+	DTC obd2.DTC
+
+	// PIDs holds the stored data of each PID in the frame
+	PIDs map[byte][]byte
+}
+
+// NewECU will create an ECU at addr with a value for every PID in package mode1, as for a warmed-up
+// spark-ignition engine at idle
+func NewECU(addr obd2.Address) *ECU {
+	e := &ECU{Address: addr}
+	o2 := mode1.O2Present{Bank1: [4]bool{true, true}, Bank2: [4]bool{true, true}}
+	e.PIDs = map[byte]Value{
+		mode1.PIDMonitorStatus:    e.monitorStatus,
+		mode1.PIDFreeze:           e.freezeDTC,
+		mode1.PIDFuelSystemStatus: Constant(byte(mode1.FuelSystemStatusClosed), 0),
+		mode1.PIDEngineLoad:       Constant(mode1.EncodeEngineLoad(0.2)),
+		mode1.PIDECT:              Constant(mode1.EncodeECT(90)),
+		mode1.PIDSTFTBank1:        Constant(mode1.EncodeFuelTrim(0)),
+		mode1.PIDLTFTBank1:        Constant(mode1.EncodeFuelTrim(0)),
+		mode1.PIDSTFTBank2:        Constant(mode1.EncodeFuelTrim(0)),
+		mode1.PIDLTFTBank2:        Constant(mode1.EncodeFuelTrim(0)),
+		mode1.PIDFuelPressure:     Constant(mode1.EncodeFuelPressure(300)),
+		mode1.PIDIntakeMAP:        Constant(33),
+		mode1.PIDEngineRPM:        Constant(mode1.EncodeEngineRPM(750)...),
+		mode1.PIDVehicleSpeed:     Constant(0),
+		mode1.PIDTimingAdvance:    Constant(mode1.EncodeTimingAdvance(10)),
+		mode1.PIDIAT:              Constant(mode1.EncodeIAT(25)),
+		mode1.PIDMAFRate:          Constant(mode1.EncodeMAFRate(3.5)...),
+		mode1.PIDThrottlePos:      Constant(mode1.EncodeThrottlePos(0.15)),
+		mode1.PIDComAirStatus:     Constant(byte(mode1.ComAirStatusOff)),
+		mode1.PIDO2Present:        Constant(mode1.EncodeO2Present(o2)),
+		mode1.PIDOBDStandard:      Constant(byte(mode1.OBDStandardOBD2CARB)),
+		mode1.PIDO2PresentExt:     Constant(0x33),
+		mode1.PIDAuxInput:         Constant(mode1.EncodeAuxInput(mode1.AuxInputStatus{})),
+		mode1.PIDRunTime:          func(t time.Duration) []byte { return mode1.EncodeRunTime(t) },
+	}
+	for pid := mode1.PIDO2STFT1; pid <= mode1.PIDO2STFT8; pid++ {
+		e.PIDs[pid] = Constant(mode1.EncodeO2STFT(mode1.O2STFT{Voltage: 0.45, SensorUsed: true})...)
+	}
+	return e
+}
+
+// monitorStatus will report the MIL and DTC count from the stored DTCs, with all spark-ignition tests complete
+func (e *ECU) monitorStatus(time.Duration) []byte {
+	n := len(e.Stored)
+	if n > 0x7f {
+		n = 0x7f
+	}
+	done := mode1.TestStatus{Available: true, Complete: true}
+	return mode1.EncodeMonitorStatus(mode1.MonitorStatus{
+		MIL:        n > 0,
+		DTCCount:   n,
+		Misfire:    done,
+		FuelSystem: done,
+		Components: done,
+		Spark: &mode1.MonitorStatusSpark{
+			Catalyst:       done,
+			HeatedCatalyst: mode1.TestStatus{Complete: true},
+			EvapSystem:     done,
+			SecondaryAir:   mode1.TestStatus{Complete: true},
+			ACRefrigerant:  mode1.TestStatus{Complete: true},
+			O2Sensor:       done,
+			O2SensorHeater: done,
+			EGRSystem:      mode1.TestStatus{Complete: true},
+		},
+	})
+}
+
+// freezeDTC will report the DTC that caused the first freeze frame
+func (e *ECU) freezeDTC(time.Duration) []byte {
+	if len(e.FreezeFrames) == 0 {
+		return []byte{0, 0}
+	}
+	return obd2.EncodeDTC(e.FreezeFrames[0].DTC)
+}
+
+// accepts will return true if the ECU should answer a request sent to addr
+func (e *ECU) accepts(addr obd2.Address) bool {
+	switch {
+	case addr == obd2.AddressFunctional:
+		return true
+	case addr < 0x800:
+		return addr+8 == e.Address
+	}
+	return addr&0xffff0000|(addr&0xff)<<8|(addr>>8)&0xff == e.Address
+}
+
+// supportBitmap will return the 4 byte support bitmap for the PIDs following base. The last bit is set
+// if anything after the block is supported. ok is false if nothing after base is supported
+func supportBitmap(base byte, has func(pid byte) bool) (data []byte, ok bool) {
+	data = make([]byte, 4)
+	ok = base == 0
+	for pid := int(base) + 1; pid <= 0xff; pid++ {
+		if !has(byte(pid)) {
+			continue
+		}
+		ok = true
+		i := pid - int(base)
+		if i > 0x20 {
+			data[3] |= 1
+			break
+		}
+		data[(i-1)/8] |= 1 << uint(7-(i-1)%8)
+	}
+	return data, ok
+}
+
+// isSupportPID will return true for the PIDs that report which PIDs are supported
+func isSupportPID(pid byte) bool {
+	return pid%0x20 == 0
+}
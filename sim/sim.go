@@ -0,0 +1,242 @@
+// Package sim provides a simulated vehicle that implements obd2.Transport, for testing and demos without a car.
+package sim
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mastercactapus/obd2"
+	"github.com/mastercactapus/obd2/mode1"
+)
+
+// Negative response codes used by the simulator
+const (
+	codeServiceNotSupported     byte = 0x11
+	codeSubFunctionNotSupported byte = 0x12
+	codeIncorrectLength         byte = 0x13
+	codeConditionsNotCorrect    byte = 0x22
+)
+
+// Vehicle is a simulated vehicle made up of one or more ECUs
+type Vehicle struct {
+	// Now returns the current time. It defaults to time.Now, and can be replaced for deterministic values
+	Now func() time.Time
+
+	mx    sync.Mutex
+	ecus  []*ECU
+	start time.Time
+}
+
+// NewVehicle will create a new Vehicle with ecus. Time for each Value starts at the first request
+func NewVehicle(ecus ...*ECU) *Vehicle {
+	return &Vehicle{Now: time.Now, ecus: ecus}
+}
+
+// ECUs will return the ECUs of the vehicle. They may be modified between requests
+func (v *Vehicle) ECUs() []*ECU {
+	return v.ecus
+}
+
+// RoundTrip will answer req from every ECU it is addressed to. ECUs that have nothing to say,
+// such as when none of the requested PIDs are supported, do not respond. It satisfies the obd2.Transport interface
+func (v *Vehicle) RoundTrip(req *obd2.Request) (*obd2.Response, error) {
+	v.mx.Lock()
+	defer v.mx.Unlock()
+	now := v.Now()
+	if v.start.IsZero() {
+		v.start = now
+	}
+	t := now.Sub(v.start)
+
+	res := new(obd2.Response)
+	for _, e := range v.ecus {
+		if !e.accepts(req.Address) {
+			continue
+		}
+		data := e.handle(req, t)
+		if data == nil {
+			continue
+		}
+		if data[0] == 0x7f && req.Address == obd2.AddressFunctional && data[2] != codeConditionsNotCorrect {
+			// functional requests only get negative responses that aren't about the request being unsupported
+			continue
+		}
+		res.Messages = append(res.Messages, obd2.Message{Source: e.Address, Data: data})
+	}
+	return res, nil
+}
+
+func negative(mode, code byte) []byte {
+	return []byte{0x7f, mode, code}
+}
+
+// handle will return the response of the ECU to req, or nil if it would not respond
+func (e *ECU) handle(req *obd2.Request, t time.Duration) []byte {
+	switch req.Mode {
+	case mode1.ID:
+		return e.mode1(req.Args, t)
+	case 0x02:
+		return e.mode2(req.Args)
+	case 0x03:
+		return dtcResponse(0x03, e.Stored)
+	case 0x04:
+		return e.clear(t)
+	case 0x07:
+		return dtcResponse(0x07, e.Pending)
+	case 0x09:
+		return e.mode9(req.Args)
+	case 0x0a:
+		return dtcResponse(0x0a, e.Permanent)
+	}
+	return negative(req.Mode, codeServiceNotSupported)
+}
+
+func (e *ECU) mode1(pids []byte, t time.Duration) []byte {
+	if len(pids) == 0 || len(pids) > 6 {
+		return negative(mode1.ID, codeIncorrectLength)
+	}
+	res := []byte{mode1.ID + 0x40}
+	for _, pid := range pids {
+		if isSupportPID(pid) {
+			data, ok := supportBitmap(pid, e.hasPID)
+			if ok {
+				res = append(append(res, pid), data...)
+			}
+			continue
+		}
+		val, ok := e.PIDs[pid]
+		if !ok {
+			continue
+		}
+		res = append(append(res, pid), val(t)...)
+	}
+	if len(res) == 1 {
+		return nil
+	}
+	return res
+}
+
+func (e *ECU) hasPID(pid byte) bool {
+	_, ok := e.PIDs[pid]
+	return ok
+}
+
+func (e *ECU) mode2(args []byte) []byte {
+	if len(args) == 0 || len(args)%2 != 0 {
+		return negative(0x02, codeIncorrectLength)
+	}
+	res := []byte{0x42}
+	for i := 0; i < len(args); i += 2 {
+		pid, n := args[i], int(args[i+1])
+		if n >= len(e.FreezeFrames) {
+			continue
+		}
+		f := e.FreezeFrames[n]
+		has := func(pid byte) bool {
+			_, ok := f.PIDs[pid]
+			return ok || pid == mode1.PIDFreeze
+		}
+		switch {
+		case isSupportPID(pid):
+			data, ok := supportBitmap(pid, has)
+			if !ok {
+				continue
+			}
+			res = append(append(res, pid, byte(n)), data...)
+		case pid == mode1.PIDFreeze:
+			res = append(append(res, pid, byte(n)), obd2.EncodeDTC(f.DTC)...)
+		case has(pid):
+			res = append(append(res, pid, byte(n)), f.PIDs[pid]...)
+		}
+	}
+	if len(res) == 1 {
+		return nil
+	}
+	return res
+}
+
+// dtcResponse will format codes as a CAN response to mode, with the count byte first
+func dtcResponse(mode byte, codes []obd2.DTC) []byte {
+	res := []byte{mode + 0x40, byte(len(codes))}
+	for _, d := range codes {
+		res = append(res, obd2.EncodeDTC(d)...)
+	}
+	return res
+}
+
+// clear will clear stored and pending DTCs and freeze frames, unless the engine is running
+func (e *ECU) clear(t time.Duration) []byte {
+	if rpm, ok := e.PIDs[mode1.PIDEngineRPM]; ok {
+		data := rpm(t)
+		if len(data) == 2 && (data[0] != 0 || data[1] != 0) {
+			return negative(0x04, codeConditionsNotCorrect)
+		}
+	}
+	e.Stored = nil
+	e.Pending = nil
+	e.FreezeFrames = nil
+	return []byte{0x44}
+}
+
+func (e *ECU) mode9(args []byte) []byte {
+	if len(args) != 1 {
+		return negative(0x09, codeIncorrectLength)
+	}
+	items := e.info()
+	if args[0] == 0x00 {
+		data, _ := supportBitmap(0, func(info byte) bool {
+			_, ok := items[info]
+			return ok
+		})
+		return append([]byte{0x49, 0x00}, data...)
+	}
+	data, ok := items[args[0]]
+	if !ok {
+		return nil
+	}
+	return append([]byte{0x49, args[0]}, data...)
+}
+
+// info will return the response data of each supported mode 9 info type, starting with the number of items
+func (e *ECU) info() map[byte][]byte {
+	items := make(map[byte][]byte)
+	if e.VIN != "" {
+		items[0x01] = []byte{0x05}
+		items[0x02] = append([]byte{0x01}, e.VIN...)
+	}
+	if len(e.CalibrationIDs) > 0 {
+		data := []byte{byte(len(e.CalibrationIDs))}
+		for _, id := range e.CalibrationIDs {
+			data = append(data, padText(id, 16)...)
+		}
+		items[0x03] = []byte{byte(len(e.CalibrationIDs) * 4)}
+		items[0x04] = data
+	}
+	if len(e.CVNs) > 0 {
+		data := []byte{byte(len(e.CVNs))}
+		for _, cvn := range e.CVNs {
+			data = append(data, byte(cvn>>24), byte(cvn>>16), byte(cvn>>8), byte(cvn))
+		}
+		items[0x05] = []byte{byte(len(e.CVNs))}
+		items[0x06] = data
+	}
+	if e.Name != "" {
+		acronym, name := e.Name, ""
+		if i := strings.IndexByte(e.Name, '-'); i >= 0 {
+			acronym, name = e.Name[:i], e.Name[i+1:]
+		}
+		data := append([]byte{0x01}, padText(acronym, 4)...)
+		data = append(append(data, '-'), padText(name, 15)...)
+		items[0x09] = []byte{0x05}
+		items[0x0a] = data
+	}
+	return items
+}
+
+// padText will return s as n bytes, truncated or padded with null bytes
+func padText(s string, n int) []byte {
+	data := make([]byte, n)
+	copy(data, s)
+	return data
+}
@@ -0,0 +1,141 @@
+package sim
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mastercactapus/obd2"
+	"github.com/mastercactapus/obd2/mode1"
+)
+
+func TestSupportBitmap(t *testing.T) {
+	cases := []struct {
+		name string
+		base byte
+		pids []byte
+		data []byte
+		ok   bool
+	}{
+		{"Empty", 0x00, nil, []byte{0, 0, 0, 0}, true},
+		{"EmptyNext", 0x20, nil, []byte{0, 0, 0, 0}, false},
+		{"First", 0x00, []byte{0x01, 0x0c}, []byte{0x80, 0x10, 0, 0}, true},
+		{"LastBit", 0x00, []byte{0x1f}, []byte{0, 0, 0, 0x02}, true},
+		{"Continue", 0x00, []byte{0x05, 0x42}, []byte{0x08, 0, 0, 0x01}, true},
+		{"ContinueExact", 0x00, []byte{0x21}, []byte{0, 0, 0, 0x01}, true},
+		{"Skipped", 0x20, []byte{0x0c, 0x61}, []byte{0, 0, 0, 0x01}, true},
+		{"Block", 0x40, []byte{0x0c, 0x42, 0x51}, []byte{0x40, 0, 0x80, 0}, true},
+		{"Before", 0x40, []byte{0x0c, 0x21}, []byte{0, 0, 0, 0}, false},
+	}
+	for _, c := range cases {
+		has := func(pid byte) bool { return bytes.IndexByte(c.pids, pid) >= 0 }
+		data, ok := supportBitmap(c.base, has)
+		if !bytes.Equal(data, c.data) || ok != c.ok {
+			t.Errorf("%s: got % x, %t; want % x, %t", c.name, data, ok, c.data, c.ok)
+		}
+	}
+}
+
+func TestVehicleSupport(t *testing.T) {
+	e := NewECU(0x7e8)
+	e.PIDs = map[byte]Value{
+		mode1.PIDEngineRPM:            Constant(mode1.EncodeEngineRPM(750)...),
+		mode1.PIDControlModuleVoltage: Constant(mode1.EncodeControlModuleVoltage(14)...),
+	}
+	v := NewVehicle(e)
+
+	cases := []struct {
+		pid  byte
+		data []byte
+	}{
+		{mode1.PIDSupport1, []byte{0x41, 0x00, 0x00, 0x10, 0x00, 0x01}},
+		{mode1.PIDSupport2, []byte{0x41, 0x20, 0x00, 0x00, 0x00, 0x01}},
+		{mode1.PIDSupport3, []byte{0x41, 0x40, 0x40, 0x00, 0x00, 0x00}},
+		{mode1.PIDSupport4, nil},
+	}
+	for _, c := range cases {
+		res, err := v.RoundTrip(&obd2.Request{Mode: mode1.ID, Args: []byte{c.pid}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var data []byte
+		if len(res.Messages) > 0 {
+			data = res.Messages[0].Data
+		}
+		if !bytes.Equal(data, c.data) {
+			t.Errorf("PID 0x%02x: got % x; want % x", c.pid, data, c.data)
+		}
+	}
+}
+
+func TestVehicleAddressing(t *testing.T) {
+	v := NewVehicle(NewECU(0x7e8), NewECU(0x7e9), NewECU(0x18daf110))
+
+	cases := []struct {
+		name    string
+		addr    obd2.Address
+		mode    byte
+		sources []obd2.Address
+		data    []byte
+	}{
+		{"Functional", obd2.AddressFunctional, mode1.ID, []obd2.Address{0x7e8, 0x7e9, 0x18daf110}, nil},
+		{"Physical", 0x7e0, mode1.ID, []obd2.Address{0x7e8}, nil},
+		{"Physical2", 0x7e1, mode1.ID, []obd2.Address{0x7e9}, nil},
+		{"Physical29", 0x18da10f1, mode1.ID, []obd2.Address{0x18daf110}, nil},
+		{"Nobody", 0x7e2, mode1.ID, nil, nil},
+		{"FunctionalUnsupported", obd2.AddressFunctional, 0x05, nil, nil},
+		{"PhysicalUnsupported", 0x7e0, 0x05, []obd2.Address{0x7e8}, []byte{0x7f, 0x05, codeServiceNotSupported}},
+	}
+	for _, c := range cases {
+		res, err := v.RoundTrip(&obd2.Request{Mode: c.mode, Args: []byte{mode1.PIDVehicleSpeed}, Address: c.addr})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var sources []obd2.Address
+		for _, m := range res.Messages {
+			sources = append(sources, m.Source)
+			if c.data != nil && !bytes.Equal(m.Data, c.data) {
+				t.Errorf("%s: %X responded % x; want % x", c.name, m.Source, m.Data, c.data)
+			}
+		}
+		if !reflect.DeepEqual(sources, c.sources) {
+			t.Errorf("%s: responses from %X; want %X", c.name, sources, c.sources)
+		}
+	}
+}
+
+func TestVehicleValues(t *testing.T) {
+	e := NewECU(0x7e8)
+	e.PIDs[mode1.PIDVehicleSpeed] = Script(Step{Data: []byte{0}}, Step{At: time.Minute, Data: []byte{50}})
+	v := NewVehicle(e)
+
+	// Now is replaced after the vehicle is created, so the clock starts at the first request
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	v.Now = func() time.Time { return now }
+
+	cases := []struct {
+		elapsed time.Duration
+		pid     byte
+		data    []byte
+	}{
+		{0, mode1.PIDRunTime, mode1.EncodeRunTime(0)},
+		{0, mode1.PIDVehicleSpeed, []byte{0}},
+		{90 * time.Second, mode1.PIDRunTime, mode1.EncodeRunTime(90 * time.Second)},
+		{90 * time.Second, mode1.PIDVehicleSpeed, []byte{50}},
+		{time.Hour, mode1.PIDRunTime, mode1.EncodeRunTime(time.Hour)},
+		{time.Hour, mode1.PIDECT, []byte{mode1.EncodeECT(90)}},
+	}
+	start := now
+	for _, c := range cases {
+		now = start.Add(c.elapsed)
+		res, err := v.RoundTrip(&obd2.Request{Mode: mode1.ID, Args: []byte{c.pid}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := append([]byte{0x41, c.pid}, c.data...)
+		if len(res.Messages) != 1 || !bytes.Equal(res.Messages[0].Data, want) {
+			t.Errorf("PID 0x%02x at %s: got %v; want % x", c.pid, c.elapsed, res.Messages, want)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package sim
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// Value will return the raw data bytes of a PID at time t, measured from the first request to the vehicle
+type Value func(t time.Duration) []byte
+
+// Constant will return a Value that is always data
+func Constant(data ...byte) Value {
+	return func(time.Duration) []byte { return data }
+}
+
+// Step is a single step in a Script
+type Step struct {
+	// At is the time the step starts
+	At time.Duration
+
+	// Data is the value from At until the next step
+	Data []byte
+}
+
+// Script will return a Value that steps through each of steps. Steps must be in order of At. Before the
+// first step, the first step's data is used
+func Script(steps ...Step) Value {
+	return func(t time.Duration) []byte {
+		if len(steps) == 0 {
+			return nil
+		}
+		data := steps[0].Data
+		for _, s := range steps {
+			if s.At > t {
+				break
+			}
+			data = s.Data
+		}
+		return data
+	}
+}
+
+// Uint16 will return a Value of the 2 byte big-endian value returned by fn
+func Uint16(fn func(t time.Duration) uint16) Value {
+	return func(t time.Duration) []byte {
+		var data [2]byte
+		binary.BigEndian.PutUint16(data[:], fn(t))
+		return data[:]
+	}
+}
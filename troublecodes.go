@@ -2,7 +2,9 @@ package obd2
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
+	"strings"
 )
 
 // DTC represents a single trouble code
@@ -16,7 +18,7 @@ type DTC struct {
 	// System represents where the trouble code came from
 	System DTCSystem
 
-	// Fault is the actual fault-index of this code
+	// Fault is the actual fault-index of this code, the last two (hex) digits
 	Fault int
 }
 type DTCType rune
@@ -46,24 +48,26 @@ const (
 	DTCSystemTransimission2  DTCSystem = '8'
 )
 
-// String returns the string representation of the trouble code, as it would appear on a scanner
+// String returns the string representation of the trouble code, as it would appear on a scanner. A Fault that does
+// not fit in 2 hex digits is shown in parentheses instead (e.g. "P01(fault 511)")
 func (d DTC) String() string {
-	if d.Fault < 0 || d.Fault > 100 {
-		panic("fault value out of bounds")
+	if d.Fault < 0 || d.Fault > 0xff {
+		return fmt.Sprintf("%c%c%c(fault %d)", d.Type, d.Category, d.System, d.Fault)
 	}
-	return string(d.Type) + string(d.Category) + string(d.System) + strconv.Itoa(d.Fault)
+	return fmt.Sprintf("%c%c%c%02X", d.Type, d.Category, d.System, d.Fault)
 }
 
-// ParseDTC will parse the trouble code into it's relevant parts. The code must be 5 characters long.
+// ParseDTC will parse the trouble code into it's relevant parts. The code must be 5 characters long, and is not case sensitive.
 func ParseDTC(s string) (*DTC, error) {
 	if len(s) != 5 {
 		return nil, errors.New("invalid length")
 	}
-	f, err := strconv.Atoi(s[3:])
+	s = strings.ToUpper(s)
+	f, err := strconv.ParseUint(s[3:], 16, 8)
 	if err != nil {
 		return nil, err
 	}
-	d := &DTC{Fault: f}
+	d := &DTC{Fault: int(f)}
 	switch s[0] {
 	case 'P', 'B', 'C', 'U':
 		d.Type = DTCType(s[0])
@@ -71,17 +75,46 @@ func ParseDTC(s string) (*DTC, error) {
 		return nil, errors.New("bad type specifier")
 	}
 	switch s[1] {
-	case '0', '1':
+	case '0', '1', '2', '3':
 		d.Category = DTCCategory(s[1])
 	default:
 		return nil, errors.New("bad category specifier")
 	}
-	switch s[3] {
-	case '1', '2', '3', '4', '5', '6', '7', '8':
-		d.System = DTCSystem(s[3])
+	switch {
+	case s[2] >= '0' && s[2] <= '9', s[2] >= 'A' && s[2] <= 'F':
+		d.System = DTCSystem(s[2])
 	default:
 		return nil, errors.New("invalid system specifier")
 	}
 
 	return d, nil
 }
+
+var dtcTypes = [4]DTCType{DTCTypePowertrain, DTCTypeChassis, DTCTypeBody, DTCTypeNetwork}
+
+const hexDigits = "0123456789ABCDEF"
+
+// DecodeDTC will decode a trouble code from the two byte format used by modes 2, 3, 7 and 0A. data must be 2 bytes
+func DecodeDTC(data []byte) DTC {
+	return DTC{
+		Type:     dtcTypes[data[0]>>6],
+		Category: DTCCategory('0' + data[0]>>4&0x3),
+		System:   DTCSystem(hexDigits[data[0]&0xf]),
+		Fault:    int(data[1]),
+	}
+}
+
+// EncodeDTC will encode d to the two byte format used by modes 2, 3, 7 and 0A
+func EncodeDTC(d DTC) []byte {
+	var a byte
+	for i, t := range dtcTypes {
+		if t == d.Type {
+			a = byte(i) << 6
+		}
+	}
+	a |= byte(d.Category-'0') & 0x3 << 4
+	if i := strings.IndexRune(hexDigits, rune(d.System)); i >= 0 {
+		a |= byte(i)
+	}
+	return []byte{a, byte(d.Fault)}
+}
@@ -0,0 +1,328 @@
+// Package units provides unit-carrying types for the physical values reported by a vehicle, with conversion
+// between metric and imperial units
+package units
+
+import (
+	"strconv"
+	"time"
+)
+
+// System is a system of units used when formatting a Quantity
+type System int
+
+// Systems of units
+const (
+	Metric System = iota
+	Imperial
+)
+
+// Unit is the symbol of a unit of measure
+type Unit string
+
+// Units of measure
+const (
+	UnitKPa         Unit = "kPa"
+	UnitPa          Unit = "Pa"
+	UnitPSI         Unit = "psi"
+	UnitCelsius     Unit = "°C"
+	UnitFahrenheit  Unit = "°F"
+	UnitKPH         Unit = "km/h"
+	UnitMPH         Unit = "mph"
+	UnitGramsPerSec Unit = "g/s"
+	UnitKgPerHour   Unit = "kg/h"
+	UnitLbPerMin    Unit = "lb/min"
+	UnitKm          Unit = "km"
+	UnitMiles       Unit = "mi"
+	UnitSeconds     Unit = "s"
+	UnitMinutes     Unit = "min"
+	UnitPercent     Unit = "%"
+	UnitVolts       Unit = "V"
+)
+
+// Quantity is a value with a unit
+type Quantity interface {
+	// Value will return the value and its unit in sys
+	Value(sys System) (float64, Unit)
+
+	// Format will format the value and unit in sys, with prec digits after the decimal point
+	Format(sys System, prec int) string
+}
+
+// format will format v with prec digits after the decimal point, followed by u
+func format(v float64, u Unit, prec int) string {
+	s := strconv.FormatFloat(v, 'f', prec, 64)
+	if u == UnitPercent {
+		return s + string(u)
+	}
+	return s + " " + string(u)
+}
+
+// Pressure is a pressure in kPa
+type Pressure float64
+
+// PSI will return a Pressure from a value in psi
+func PSI(v float64) Pressure { return Pressure(v / psiPerKPa) }
+
+const psiPerKPa = 0.1450377377
+
+// KPa will return the pressure in kPa
+func (p Pressure) KPa() float64 { return float64(p) }
+
+// PSI will return the pressure in psi
+func (p Pressure) PSI() float64 { return float64(p) * psiPerKPa }
+
+// InHg will return the pressure in inches of mercury
+func (p Pressure) InHg() float64 { return float64(p) * 0.2952998751 }
+
+// Bar will return the pressure in bar
+func (p Pressure) Bar() float64 { return float64(p) / 100 }
+
+// Value will return the pressure in kPa (Metric) or psi (Imperial)
+func (p Pressure) Value(sys System) (float64, Unit) {
+	if sys == Imperial {
+		return p.PSI(), UnitPSI
+	}
+	return p.KPa(), UnitKPa
+}
+
+// Format will format the pressure in kPa (Metric) or psi (Imperial)
+func (p Pressure) Format(sys System, prec int) string {
+	v, u := p.Value(sys)
+	return format(v, u, prec)
+}
+
+func (p Pressure) String() string { return p.Format(Metric, 1) }
+
+// Temperature is a temperature in degrees Celsius
+type Temperature float64
+
+// Fahrenheit will return a Temperature from a value in degrees Fahrenheit
+func Fahrenheit(v float64) Temperature { return Temperature((v - 32) * 5 / 9) }
+
+// Celsius will return the temperature in degrees Celsius
+func (t Temperature) Celsius() float64 { return float64(t) }
+
+// Fahrenheit will return the temperature in degrees Fahrenheit
+func (t Temperature) Fahrenheit() float64 { return float64(t)*9/5 + 32 }
+
+// Kelvin will return the temperature in Kelvin
+func (t Temperature) Kelvin() float64 { return float64(t) + 273.15 }
+
+// Value will return the temperature in °C (Metric) or °F (Imperial)
+func (t Temperature) Value(sys System) (float64, Unit) {
+	if sys == Imperial {
+		return t.Fahrenheit(), UnitFahrenheit
+	}
+	return t.Celsius(), UnitCelsius
+}
+
+// Format will format the temperature in °C (Metric) or °F (Imperial)
+func (t Temperature) Format(sys System, prec int) string {
+	v, u := t.Value(sys)
+	return format(v, u, prec)
+}
+
+func (t Temperature) String() string { return t.Format(Metric, 0) }
+
+const kmPerMile = 1.609344
+
+// Speed is a speed in km/h
+type Speed float64
+
+// MPH will return a Speed from a value in miles per hour
+func MPH(v float64) Speed { return Speed(v * kmPerMile) }
+
+// KPH will return the speed in km/h
+func (s Speed) KPH() float64 { return float64(s) }
+
+// MPH will return the speed in miles per hour
+func (s Speed) MPH() float64 { return float64(s) / kmPerMile }
+
+// Value will return the speed in km/h (Metric) or mph (Imperial)
+func (s Speed) Value(sys System) (float64, Unit) {
+	if sys == Imperial {
+		return s.MPH(), UnitMPH
+	}
+	return s.KPH(), UnitKPH
+}
+
+// Format will format the speed in km/h (Metric) or mph (Imperial)
+func (s Speed) Format(sys System, prec int) string {
+	v, u := s.Value(sys)
+	return format(v, u, prec)
+}
+
+func (s Speed) String() string { return s.Format(Metric, 0) }
+
+// Distance is a distance in km
+type Distance float64
+
+// Miles will return a Distance from a value in miles
+func Miles(v float64) Distance { return Distance(v * kmPerMile) }
+
+// Km will return the distance in km
+func (d Distance) Km() float64 { return float64(d) }
+
+// Miles will return the distance in miles
+func (d Distance) Miles() float64 { return float64(d) / kmPerMile }
+
+// Value will return the distance in km (Metric) or miles (Imperial)
+func (d Distance) Value(sys System) (float64, Unit) {
+	if sys == Imperial {
+		return d.Miles(), UnitMiles
+	}
+	return d.Km(), UnitKm
+}
+
+// Format will format the distance in km (Metric) or miles (Imperial)
+func (d Distance) Format(sys System, prec int) string {
+	v, u := d.Value(sys)
+	return format(v, u, prec)
+}
+
+func (d Distance) String() string { return d.Format(Metric, 0) }
+
+const gramsPerLb = 453.59237
+
+// Flow is a mass flow rate in grams/sec
+type Flow float64
+
+// LbPerMin will return a Flow from a value in pounds per minute
+func LbPerMin(v float64) Flow { return Flow(v * gramsPerLb / 60) }
+
+// GramsPerSec will return the flow rate in grams/sec
+func (f Flow) GramsPerSec() float64 { return float64(f) }
+
+// KgPerHour will return the flow rate in kg/h
+func (f Flow) KgPerHour() float64 { return float64(f) * 3.6 }
+
+// LbPerMin will return the flow rate in pounds per minute
+func (f Flow) LbPerMin() float64 { return float64(f) * 60 / gramsPerLb }
+
+// Value will return the flow rate in g/s (Metric) or lb/min (Imperial)
+func (f Flow) Value(sys System) (float64, Unit) {
+	if sys == Imperial {
+		return f.LbPerMin(), UnitLbPerMin
+	}
+	return f.GramsPerSec(), UnitGramsPerSec
+}
+
+// Format will format the flow rate in g/s (Metric) or lb/min (Imperial)
+func (f Flow) Format(sys System, prec int) string {
+	v, u := f.Value(sys)
+	return format(v, u, prec)
+}
+
+func (f Flow) String() string { return f.Format(Metric, 2) }
+
+// Duration is a length of time. It is the same in both systems
+type Duration time.Duration
+
+// Value will return the duration in seconds
+func (d Duration) Value(System) (float64, Unit) {
+	return time.Duration(d).Seconds(), UnitSeconds
+}
+
+// Format will format the duration as with time.Duration, rounded to prec digits after the decimal point of a second
+func (d Duration) Format(_ System, prec int) string {
+	r := time.Second
+	for i := 0; i < prec && r > 1; i++ {
+		r /= 10
+	}
+	return time.Duration(d).Round(r).String()
+}
+
+func (d Duration) String() string { return d.Format(Metric, 0) }
+
+// Ratio is a percentage as a fraction (1 is 100%). It is the same in both systems
+type Ratio float64
+
+// Percent will return the ratio as a percentage (100 is 100%)
+func (r Ratio) Percent() float64 { return float64(r) * 100 }
+
+// Value will return the ratio as a percentage
+func (r Ratio) Value(System) (float64, Unit) { return r.Percent(), UnitPercent }
+
+// Format will format the ratio as a percentage
+func (r Ratio) Format(sys System, prec int) string {
+	v, u := r.Value(sys)
+	return format(v, u, prec)
+}
+
+func (r Ratio) String() string { return r.Format(Metric, 1) }
+
+// Voltage is an electric potential in volts. It is the same in both systems
+type Voltage float64
+
+// Volts will return the voltage in volts
+func (v Voltage) Volts() float64 { return float64(v) }
+
+// Value will return the voltage in volts
+func (v Voltage) Value(System) (float64, Unit) { return v.Volts(), UnitVolts }
+
+// Format will format the voltage in volts
+func (v Voltage) Format(sys System, prec int) string {
+	f, u := v.Value(sys)
+	return format(f, u, prec)
+}
+
+func (v Voltage) String() string { return v.Format(Metric, 2) }
+
+// Scalar is a value in a unit with no conversion. It is the same in both systems
+type Scalar struct {
+	V float64
+	U Unit
+}
+
+// Value will return the value and unit unchanged
+func (s Scalar) Value(System) (float64, Unit) { return s.V, s.U }
+
+// Format will format the value and unit, if any
+func (s Scalar) Format(_ System, prec int) string {
+	if s.U == "" {
+		return strconv.FormatFloat(s.V, 'f', prec, 64)
+	}
+	return format(s.V, s.U, prec)
+}
+
+func (s Scalar) String() string { return s.Format(Metric, 2) }
+
+// Of will return the Quantity for v in unit u. Values in UnitPercent are a fraction (1 is 100%), as returned by the
+// mode1 decoders. Units that have no type in this package are returned as a Scalar
+func Of(v float64, u Unit) Quantity {
+	switch u {
+	case UnitKPa:
+		return Pressure(v)
+	case UnitPa:
+		return Pressure(v / 1000)
+	case UnitPSI:
+		return PSI(v)
+	case UnitCelsius:
+		return Temperature(v)
+	case UnitFahrenheit:
+		return Fahrenheit(v)
+	case UnitKPH:
+		return Speed(v)
+	case UnitMPH:
+		return MPH(v)
+	case UnitGramsPerSec:
+		return Flow(v)
+	case UnitKgPerHour:
+		return Flow(v / 3.6)
+	case UnitLbPerMin:
+		return LbPerMin(v)
+	case UnitKm:
+		return Distance(v)
+	case UnitMiles:
+		return Miles(v)
+	case UnitSeconds:
+		return Duration(v * float64(time.Second))
+	case UnitMinutes:
+		return Duration(v * float64(time.Minute))
+	case UnitPercent:
+		return Ratio(v)
+	case UnitVolts:
+		return Voltage(v)
+	}
+	return Scalar{V: v, U: u}
+}
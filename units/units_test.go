@@ -0,0 +1,111 @@
+package units
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestConversions(t *testing.T) {
+	cases := []struct {
+		name string
+
+		// metric and imperial are the same quantity, built from a metric and an imperial value
+		metric, imperial Quantity
+
+		m, i   float64
+		mu, iu Unit
+	}{
+		{"Pressure", Pressure(100), PSI(14.50377377), 100, 14.50377377, UnitKPa, UnitPSI},
+		{"Pressure/Zero", Pressure(0), PSI(0), 0, 0, UnitKPa, UnitPSI},
+		{"Temperature", Temperature(100), Fahrenheit(212), 100, 212, UnitCelsius, UnitFahrenheit},
+		{"Temperature/Negative", Temperature(-40), Fahrenheit(-40), -40, -40, UnitCelsius, UnitFahrenheit},
+		{"Temperature/Freezing", Temperature(0), Fahrenheit(32), 0, 32, UnitCelsius, UnitFahrenheit},
+		{"Speed", Speed(100), MPH(62.13711922), 100, 62.13711922, UnitKPH, UnitMPH},
+		{"Distance", Distance(1.609344), Miles(1), 1.609344, 1, UnitKm, UnitMiles},
+		{"Flow", Flow(7.5598728), LbPerMin(1), 7.5598728, 1, UnitGramsPerSec, UnitLbPerMin},
+		{"Of/Pa", Of(101325, UnitPa), Of(14.69594878, UnitPSI), 101.325, 14.69594878, UnitKPa, UnitPSI},
+		{"Of/KgPerHour", Of(36, UnitKgPerHour), Of(1.322773573, UnitLbPerMin), 10, 1.322773573, UnitGramsPerSec, UnitLbPerMin},
+	}
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-6 }
+	for _, c := range cases {
+		for _, q := range []Quantity{c.metric, c.imperial} {
+			if v, u := q.Value(Metric); !near(v, c.m) || u != c.mu {
+				t.Errorf("%s: metric value of %v is %v %s; want %v %s", c.name, q, v, u, c.m, c.mu)
+			}
+			if v, u := q.Value(Imperial); !near(v, c.i) || u != c.iu {
+				t.Errorf("%s: imperial value of %v is %v %s; want %v %s", c.name, q, v, u, c.i, c.iu)
+			}
+		}
+	}
+}
+
+func TestConversionAccessors(t *testing.T) {
+	cases := []struct {
+		name      string
+		got, want float64
+	}{
+		{"Pressure.InHg", Pressure(101.325).InHg(), 29.92126},
+		{"Pressure.Bar", Pressure(250).Bar(), 2.5},
+		{"Temperature.Kelvin", Temperature(25).Kelvin(), 298.15},
+		{"Flow.KgPerHour", Flow(10).KgPerHour(), 36},
+		{"Ratio.Percent", Ratio(0.42).Percent(), 42},
+	}
+	for _, c := range cases {
+		if math.Abs(c.got-c.want) > 1e-4 {
+			t.Errorf("%s: got %v; want %v", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	cases := []struct {
+		q    Quantity
+		sys  System
+		prec int
+		want string
+	}{
+		{Pressure(101.3), Metric, 1, "101.3 kPa"},
+		{Pressure(101.3), Imperial, 2, "14.69 psi"},
+		{Pressure(101.3), Metric, 0, "101 kPa"},
+		{Temperature(90), Metric, 0, "90 °C"},
+		{Temperature(90), Imperial, 1, "194.0 °F"},
+		{Speed(100), Imperial, 0, "62 mph"},
+		{Distance(42.195), Metric, 3, "42.195 km"},
+		{Flow(3.5), Imperial, 3, "0.463 lb/min"},
+		{Ratio(0.255), Metric, 1, "25.5%"},
+		{Ratio(0.255), Imperial, 0, "26%"},
+		{Of(0.5, UnitPercent), Metric, 1, "50.0%"},
+		{Voltage(14.1234), Imperial, 2, "14.12 V"},
+		{Duration(1500 * time.Millisecond), Metric, 1, "1.5s"},
+		{Duration(1500 * time.Millisecond), Imperial, 0, "2s"},
+		{Of(90, UnitMinutes), Metric, 0, "1h30m0s"},
+		{Scalar{V: 3}, Metric, 2, "3.00"},
+		{Of(5, "ppm"), Imperial, 1, "5.0 ppm"},
+	}
+	for _, c := range cases {
+		if got := c.q.Format(c.sys, c.prec); got != c.want {
+			t.Errorf("%#v.Format(%d, %d) = %q; want %q", c.q, c.sys, c.prec, got, c.want)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	cases := []struct {
+		q    interface{ String() string }
+		want string
+	}{
+		{Pressure(101.325), "101.3 kPa"},
+		{Temperature(89.6), "90 °C"},
+		{Speed(88.4), "88 km/h"},
+		{Distance(12.5), "12 km"},
+		{Flow(3.456), "3.46 g/s"},
+		{Ratio(0.1234), "12.3%"},
+		{Voltage(14.1), "14.10 V"},
+	}
+	for _, c := range cases {
+		if got := c.q.String(); got != c.want {
+			t.Errorf("%#v.String() = %q; want %q", c.q, got, c.want)
+		}
+	}
+}